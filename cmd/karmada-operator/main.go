@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command karmada-operator runs the operator's controller-runtime manager:
+// the Karmada CRD's mutating and validating admission webhooks, the
+// certificate rotation controller, the search-indexer controller, and (when
+// --signing-bind-address is set) the mTLS CSR signing server karmadactl
+// register and the karmada-agent use to obtain certificates on demand.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/klogr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	searchapi "github.com/karmada-io/karmada/pkg/apis/search"
+
+	operatorapi "github.com/firefly-io/karmada-operator/pkg/apis/operator/v1alpha1"
+	certscontroller "github.com/firefly-io/karmada-operator/pkg/controller/certs"
+	searchcontroller "github.com/firefly-io/karmada-operator/pkg/controller/search"
+	certsutil "github.com/firefly-io/karmada-operator/pkg/util/certs"
+	acmeissuer "github.com/firefly-io/karmada-operator/pkg/util/certs/acme"
+	"github.com/firefly-io/karmada-operator/pkg/util/certs/signing"
+	karmadawebhook "github.com/firefly-io/karmada-operator/pkg/webhook/karmada"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	if err := operatorapi.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	if err := searchapi.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+}
+
+func main() {
+	var metricsAddr string
+	var probeAddr string
+	var enableLeaderElection bool
+	var webhookCertDir string
+	var signingAddr string
+	var signingCASecretNamespace string
+	var signingCASecretName string
+	var signingServerSecretName string
+	var signingSerialConfigMapNamespace string
+	var signingSerialConfigMapName string
+	var clusterKubeconfigSecretNamespace string
+	var acmeHTTP01Addr string
+
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metrics endpoint binds to.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.BoolVar(&enableLeaderElection, "leader-elect", false, "Enable leader election for the controller manager.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs", "Directory holding the admission webhook's serving certificate.")
+	flag.StringVar(&signingAddr, "signing-bind-address", "", "The address the CSR signing server binds to. Disabled when empty.")
+	flag.StringVar(&signingCASecretNamespace, "signing-ca-secret-namespace", "karmada-system", "Namespace of the Secret holding the CA the signing server signs against and verifies client certificates with.")
+	flag.StringVar(&signingCASecretName, "signing-ca-secret-name", "karmada-ca", "Name of the Secret holding the signing CA's \"tls.crt\"/\"tls.key\".")
+	flag.StringVar(&signingServerSecretName, "signing-server-secret-name", "karmada-operator-signing-server-cert", "Name of the Secret holding the signing server's own serving certificate (\"tls.crt\"/\"tls.key\"), issued from the signing CA.")
+	flag.StringVar(&signingSerialConfigMapNamespace, "signing-serial-configmap-namespace", "karmada-system", "Namespace of the ConfigMap the signing server records issued certificate serials in for revocation-list generation.")
+	flag.StringVar(&signingSerialConfigMapName, "signing-serial-configmap-name", "karmada-operator-signing-serials", "Name of the ConfigMap the signing server records issued certificate serials in.")
+	flag.StringVar(&clusterKubeconfigSecretNamespace, "cluster-kubeconfig-secret-namespace", "karmada-system", "Namespace holding the \"<cluster>-kubeconfig\" Secrets the search-indexer reads member cluster kubeconfigs from.")
+	flag.StringVar(&acmeHTTP01Addr, "acme-http01-bind-address", "", "The address the ACME http-01 challenge responder binds to. Disabled when empty; required for any Karmada that sets apiServer.servingCert.acme, since those certificates are always authorized via http-01.")
+	klog.InitFlags(nil)
+	flag.Parse()
+
+	ctrl.SetLogger(klogr.New())
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:                 scheme,
+		MetricsBindAddress:     metricsAddr,
+		HealthProbeBindAddress: probeAddr,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       "karmada-operator.karmada.io",
+		CertDir:                webhookCertDir,
+	})
+	if err != nil {
+		klog.ErrorS(err, "Unable to start manager")
+		os.Exit(1)
+	}
+
+	mgr.GetWebhookServer().Register("/mutate-operator-karmada-io-v1alpha1-karmada", &webhook.Admission{Handler: karmadawebhook.NewMutatingHandler()})
+	mgr.GetWebhookServer().Register("/validate-operator-karmada-io-v1alpha1-karmada", &webhook.Admission{Handler: karmadawebhook.NewValidatingHandler()})
+
+	rotationController := certscontroller.NewRotationController(mgr.GetClient(), mgr.GetEventRecorderFor("karmada-operator"))
+	if acmeHTTP01Addr != "" {
+		responder := acmeissuer.NewChallengeResponder()
+		rotationController.ACMEChallengeResponder = responder
+		if err := mgr.Add(&acmeissuer.Runnable{Responder: responder, Addr: acmeHTTP01Addr}); err != nil {
+			klog.ErrorS(err, "Unable to register ACME http-01 challenge responder with the manager")
+			os.Exit(1)
+		}
+	}
+	if err := rotationController.SetupWithManager(mgr); err != nil {
+		klog.ErrorS(err, "Unable to set up certificate rotation controller")
+		os.Exit(1)
+	}
+
+	clusterClients := searchcontroller.NewClusterClientFactory(mgr.GetClient(), clusterKubeconfigSecretNamespace)
+	indexController := &searchcontroller.IndexController{
+		Client:                  mgr.GetClient(),
+		DynamicClientForCluster: clusterClients.DynamicClientForCluster,
+		RESTMapperForCluster:    clusterClients.RESTMapperForCluster,
+	}
+	if err := indexController.SetupWithManager(mgr); err != nil {
+		klog.ErrorS(err, "Unable to set up search-indexer controller")
+		os.Exit(1)
+	}
+
+	if signingAddr != "" {
+		runnable, err := setupSigningServer(mgr.GetConfig(), signingAddr, signingCASecretNamespace, signingCASecretName, signingServerSecretName, signingSerialConfigMapNamespace, signingSerialConfigMapName)
+		if err != nil {
+			klog.ErrorS(err, "Unable to set up CSR signing server")
+			os.Exit(1)
+		}
+		if err := mgr.Add(runnable); err != nil {
+			klog.ErrorS(err, "Unable to register CSR signing server with the manager")
+			os.Exit(1)
+		}
+	}
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		klog.ErrorS(err, "Unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		klog.ErrorS(err, "Unable to set up ready check")
+		os.Exit(1)
+	}
+
+	klog.InfoS("Starting manager")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		klog.ErrorS(err, "Problem running manager")
+		os.Exit(1)
+	}
+}
+
+// setupSigningServer builds the mTLS-authenticated CSR signing server as a
+// manager.Runnable: the CA it signs against and verifies karmadactl
+// register/karmada-agent callers with comes from the caSecretName Secret, and
+// its own serving certificate from the serverSecretName Secret. Both are read
+// once here, at startup: later changes to those Secrets are not picked up by
+// the running server, and the rotation controller does not renew or rotate
+// them.
+func setupSigningServer(cfg *rest.Config, addr, caSecretNamespace, caSecretName, serverSecretName, serialConfigMapNamespace, serialConfigMapName string) (*signing.Runnable, error) {
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("unable to build client: %v", err)
+	}
+	ctx := context.Background()
+
+	caSecret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: caSecretNamespace, Name: caSecretName}, caSecret); err != nil {
+		return nil, fmt.Errorf("unable to fetch signing CA Secret %s/%s: %v", caSecretNamespace, caSecretName, err)
+	}
+	caPair := certsutil.CAKeyPair{CertPEM: caSecret.Data["tls.crt"], KeyPEM: caSecret.Data["tls.key"]}
+
+	serverSecret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: caSecretNamespace, Name: serverSecretName}, serverSecret); err != nil {
+		return nil, fmt.Errorf("unable to fetch signing server Secret %s/%s: %v", caSecretNamespace, serverSecretName, err)
+	}
+	serverCert, err := tls.X509KeyPair(serverSecret.Data["tls.crt"], serverSecret.Data["tls.key"])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signing server certificate: %v", err)
+	}
+
+	clientCAPool := x509.NewCertPool()
+	if !clientCAPool.AppendCertsFromPEM(caPair.CertPEM) {
+		return nil, fmt.Errorf("unable to parse signing CA certificate from %s/%s", caSecretNamespace, caSecretName)
+	}
+
+	signer := &signing.Signer{
+		CA:     certsutil.SecretCAProvider{CAs: map[string]certsutil.CAKeyPair{"karmada": caPair}},
+		CAName: "karmada",
+		SerialStore: &signing.SerialStore{
+			Client:    c,
+			Namespace: serialConfigMapNamespace,
+			Name:      serialConfigMapName,
+		},
+	}
+
+	return &signing.Runnable{
+		Server:    &signing.Server{Signer: signer},
+		Addr:      addr,
+		TLSConfig: signing.NewMTLSConfig(serverCert, clientCAPool),
+	}, nil
+}
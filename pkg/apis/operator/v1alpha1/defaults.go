@@ -0,0 +1,32 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SetDefaults_Karmada fills in the defaults the mutating webhook applies to
+// every Karmada object before it is persisted.
+func SetDefaults_Karmada(karmada *Karmada) {
+	if karmada.Spec.APIServer == nil {
+		karmada.Spec.APIServer = &APIServerSpec{}
+	}
+	if karmada.Spec.APIServer.ServiceType == "" {
+		karmada.Spec.APIServer.ServiceType = corev1.ServiceTypeClusterIP
+	}
+}
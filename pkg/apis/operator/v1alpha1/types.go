@@ -0,0 +1,207 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Karmada represents the desired state of a single Karmada control plane
+// instance managed by the operator.
+type Karmada struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KarmadaSpec   `json:"spec,omitempty"`
+	Status KarmadaStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KarmadaList contains a list of Karmada.
+type KarmadaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Karmada `json:"items"`
+}
+
+// KarmadaSpec describes the desired Karmada control plane.
+type KarmadaSpec struct {
+	// Components configures the image and runtime settings of each Karmada
+	// control plane component.
+	Components ComponentsSpec `json:"components,omitempty"`
+
+	// APIServer configures the karmada-apiserver Service and the serving
+	// certificate it presents.
+	// +optional
+	APIServer *APIServerSpec `json:"apiServer,omitempty"`
+
+	// CertificateAuthority names, per CA, a user-supplied CA Secret. The
+	// validating webhook checks each referenced Secret; certificate
+	// generation and rotation do not read this field, and still use the
+	// CAs in the Karmada instance's certificate Secret.
+	// +optional
+	CertificateAuthority *CertificateAuthorityConfig `json:"certificateAuthority,omitempty"`
+
+	// RenewCertificates forces every managed certificate to be treated as
+	// due for rotation on the next reconcile, regardless of its remaining
+	// validity.
+	// +optional
+	RenewCertificates *bool `json:"renewCertificates,omitempty"`
+}
+
+// ComponentsSpec groups the per-component settings for every Deployment the
+// operator manages.
+type ComponentsSpec struct {
+	// +optional
+	KarmadaAPIServer *ComponentSpec `json:"karmadaAPIServer,omitempty"`
+	// +optional
+	KarmadaControllerManager *ComponentSpec `json:"karmadaControllerManager,omitempty"`
+	// +optional
+	KarmadaScheduler *ComponentSpec `json:"karmadaScheduler,omitempty"`
+	// +optional
+	KarmadaWebhook *ComponentSpec `json:"karmadaWebhook,omitempty"`
+	// +optional
+	KarmadaAggregatedAPIServer *ComponentSpec `json:"karmadaAggregatedAPIServer,omitempty"`
+	// +optional
+	Etcd *ComponentSpec `json:"etcd,omitempty"`
+}
+
+// ComponentSpec is the common image/runtime configuration shared by every
+// Karmada control plane component.
+type ComponentSpec struct {
+	// +optional
+	ImageRepository string `json:"imageRepository,omitempty"`
+	// +optional
+	ImageTag string `json:"imageTag,omitempty"`
+
+	// External configures a pre-existing, externally managed instance of
+	// this component instead of having the operator run one. Only
+	// meaningful on the etcd component.
+	// +optional
+	External *ExternalEtcdConfig `json:"external,omitempty"`
+}
+
+// ExternalEtcdConfig points the operator at an already-running etcd
+// cluster instead of having it deploy one.
+type ExternalEtcdConfig struct {
+	// Endpoints are the client URLs of the external etcd cluster.
+	// +optional
+	Endpoints []string `json:"endpoints,omitempty"`
+
+	// SecretRef names the Secret carrying the client credentials
+	// ("ca.crt", "client.crt", "client.key") used to reach Endpoints.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// APIServerSpec configures the karmada-apiserver Service and its public
+// serving certificate.
+type APIServerSpec struct {
+	// ServiceType is the type of Service fronting karmada-apiserver.
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// NodePort is the port karmada-apiserver is exposed on when ServiceType
+	// is NodePort.
+	// +optional
+	NodePort int32 `json:"nodePort,omitempty"`
+
+	// CertSANs are additional IPs and/or DNS names to include in the
+	// apiserver serving certificate's subjectAltName.
+	// +optional
+	CertSANs []string `json:"certSANs,omitempty"`
+
+	// ServingCert configures how the apiserver serving certificate is
+	// obtained. When unset, the operator signs it locally against the
+	// root CA like every other leaf it manages.
+	// +optional
+	ServingCert *ServingCertConfig `json:"servingCert,omitempty"`
+}
+
+// ServingCertConfig selects how karmada-apiserver's serving certificate is
+// obtained.
+type ServingCertConfig struct {
+	// ACME obtains the certificate from an ACME v2 directory (e.g. Let's
+	// Encrypt or an internal ACME server) instead of signing it locally.
+	// +optional
+	ACME *ACMEIssuerConfig `json:"acme,omitempty"`
+}
+
+// ACMEIssuerConfig configures the ACME v2 directory used to obtain
+// karmada-apiserver's serving certificate.
+type ACMEIssuerConfig struct {
+	// DirectoryURL is the ACME server's directory endpoint.
+	DirectoryURL string `json:"directoryURL"`
+
+	// Contact lists the ACME account's contact URIs, e.g.
+	// "mailto:ops@example.com".
+	// +optional
+	Contact []string `json:"contact,omitempty"`
+
+	// DNSNames are the domain names to request the certificate for. An
+	// ACME server only issues for DNS names, never IPs, so these replace
+	// rather than merely supplement whatever IPs are in Spec.APIServer.CertSANs.
+	DNSNames []string `json:"dnsNames"`
+
+	// AccountSecretRef names the Secret the ACME account's private key is
+	// persisted in.
+	AccountSecretRef corev1.LocalObjectReference `json:"accountSecretRef"`
+}
+
+// CertificateAuthorityConfig lets an operator supply one or more of the
+// CAs the operator would otherwise generate itself, one stanza per CA.
+type CertificateAuthorityConfig struct {
+	// Root is the CA karmada.crt/apiserver.crt are issued from.
+	// +optional
+	Root *CAConfig `json:"root,omitempty"`
+	// Etcd is the CA etcd-server.crt/etcd-client.crt are issued from.
+	// +optional
+	Etcd *CAConfig `json:"etcd,omitempty"`
+	// FrontProxy is the CA front-proxy-client.crt is issued from.
+	// +optional
+	FrontProxy *CAConfig `json:"frontProxy,omitempty"`
+}
+
+// CAConfig selects the provider for a single CA: a user-supplied Secret, or
+// (when SecretRef is unset) the operator's own self-signed generation.
+type CAConfig struct {
+	// SecretRef names a Secret carrying "tls.crt"/"tls.key" to use as this
+	// CA instead of having the operator generate one.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// NotAfter is an expiry for a self-signed CA. The validating webhook
+	// rejects a value in the past; the operator's CA generation does not
+	// read it, and issues CAs with its own default validity.
+	// +optional
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+}
+
+// KarmadaStatus reports the observed state of a Karmada control plane.
+type KarmadaStatus struct {
+	// Conditions report the status of distinct aspects of the control
+	// plane's lifecycle, e.g. CertificatesRenewed/CertificatesNearExpiry.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
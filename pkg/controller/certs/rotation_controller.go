@@ -0,0 +1,606 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/firefly-io/karmada-operator/pkg/apis/operator/v1alpha1"
+	certsutil "github.com/firefly-io/karmada-operator/pkg/util/certs"
+	acmeissuer "github.com/firefly-io/karmada-operator/pkg/util/certs/acme"
+)
+
+const (
+	// defaultRenewalThreshold is the fraction of a leaf certificate's total
+	// lifetime (NotAfter - NotBefore) remaining at which it is renewed.
+	// Mirrors the 1/3-remaining-validity window used by x/crypto/acme/autocert.
+	defaultRenewalThreshold = 1.0 / 3.0
+
+	// certHashAnnotation is bumped on the pod template of dependent
+	// Deployments whenever their serving certificate changes, forcing a
+	// rollout that picks up the new material.
+	certHashAnnotation = "operator.karmada.io/cert-secret-hash"
+
+	// ConditionCertificatesRenewed reports that the last reconcile renewed
+	// one or more leaf certificates or started, advanced or completed a CA
+	// rotation.
+	ConditionCertificatesRenewed = "CertificatesRenewed"
+	// ConditionCertificatesNearExpiry reports that at least one certificate
+	// is within the renewal threshold but has not yet been renewed (e.g.
+	// because the CA key could not be loaded).
+	ConditionCertificatesNearExpiry = "CertificatesNearExpiry"
+
+	reasonRenewed    = "CertificateRenewed"
+	reasonNearExpiry = "CertificateNearExpiry"
+)
+
+// leafSpec describes a single leaf certificate stored in a component Secret,
+// the CA pair it was issued from, and the Deployments that must be rolled
+// when it changes.
+type leafSpec struct {
+	certKey     string
+	keyKey      string
+	caCertKey   string
+	caKeyKey    string
+	deployments []string
+}
+
+// componentLeaves enumerates every leaf certificate GenCerts issues, keyed
+// by the Secret data key it lives under.
+var componentLeaves = []leafSpec{
+	{certKey: "apiserver.crt", keyKey: "apiserver.key", caCertKey: "ca.crt", caKeyKey: "ca.key",
+		deployments: []string{"karmada-apiserver"}},
+	{certKey: "karmada.crt", keyKey: "karmada.key", caCertKey: "ca.crt", caKeyKey: "ca.key",
+		deployments: []string{"karmada-controller-manager", "karmada-scheduler", "karmada-webhook", "karmada-aggregated-apiserver"}},
+	{certKey: "front-proxy-client.crt", keyKey: "front-proxy-client.key", caCertKey: "front-proxy-ca.crt", caKeyKey: "front-proxy-ca.key",
+		deployments: []string{"karmada-apiserver"}},
+	{certKey: "etcd-server.crt", keyKey: "etcd-server.key", caCertKey: "etcd-ca.crt", caKeyKey: "etcd-ca.key",
+		deployments: []string{"etcd"}},
+	{certKey: "etcd-client.crt", keyKey: "etcd-client.key", caCertKey: "etcd-ca.crt", caKeyKey: "etcd-ca.key",
+		deployments: []string{"karmada-apiserver"}},
+}
+
+// leavesForCA returns every leafSpec issued from the CA stored under
+// caCertKey, used to drive leaf migration during a CA rotation.
+func leavesForCA(caCertKey string) []leafSpec {
+	var leaves []leafSpec
+	for _, leaf := range componentLeaves {
+		if leaf.caCertKey == caCertKey {
+			leaves = append(leaves, leaf)
+		}
+	}
+	return leaves
+}
+
+// caSpec describes one of the CAs GenCerts issues, identified by the Secret
+// data keys its cert/key pair lives under.
+type caSpec struct {
+	cn      string
+	certKey string
+	keyKey  string
+}
+
+// caSpecs enumerates every CA GenCerts issues and RotationController may
+// rotate.
+var caSpecs = []caSpec{
+	{cn: "karmada", certKey: "ca.crt", keyKey: "ca.key"},
+	{cn: "front-proxy-ca", certKey: "front-proxy-ca.crt", keyKey: "front-proxy-ca.key"},
+	{cn: "etcd-ca", certKey: "etcd-ca.crt", keyKey: "etcd-ca.key"},
+}
+
+// caRotationRequeue bounds how long an in-progress CA rotation waits before
+// the next reconcile migrates another batch of leaves or promotes the new
+// root, independent of the leaf renewal threshold.
+const caRotationRequeue = time.Minute
+
+// RotationController watches Karmada instances, renews leaf certificates
+// that are approaching expiry, and rolls the Deployments that consume them
+// so the new material is loaded.
+type RotationController struct {
+	client.Client
+	EventRecorder record.EventRecorder
+
+	// RenewalThreshold overrides defaultRenewalThreshold, mostly for tests.
+	RenewalThreshold float64
+	// Now allows tests to control the clock used for expiry checks.
+	Now func() time.Time
+
+	// ACMEChallengeResponder serves http-01 challenge responses for ACME
+	// serving certs. ACMEIssuerConfig has no DNS-01 provider setting, so
+	// every ACME order is authorized via http-01; nil disables http-01 and
+	// leaves ACME serving certs unobtainable.
+	ACMEChallengeResponder *acmeissuer.ChallengeResponder
+}
+
+// NewRotationController builds a RotationController ready to be registered
+// with a controller-runtime manager.
+func NewRotationController(c client.Client, recorder record.EventRecorder) *RotationController {
+	return &RotationController{
+		Client:           c,
+		EventRecorder:    recorder,
+		RenewalThreshold: defaultRenewalThreshold,
+		Now:              time.Now,
+	}
+}
+
+// SetupWithManager registers the controller with mgr, watching Karmada
+// instances and the Secrets that hold their certificate bundles.
+func (c *RotationController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorapi.Karmada{}).
+		Owns(&corev1.Secret{}).
+		Complete(c)
+}
+
+// Reconcile inspects the certificate Secret for the Karmada instance named
+// in req, renews any leaf whose remaining validity has dropped below the
+// renewal threshold, drives the CAs through their own two-phase rotation via
+// reconcileCAs, and rolls the Deployments that consume whatever changed.
+func (c *RotationController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	karmada := &operatorapi.Karmada{}
+	if err := c.Get(ctx, req.NamespacedName, karmada); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	secret := &corev1.Secret{}
+	secretName := types.NamespacedName{Namespace: karmada.Namespace, Name: karmada.Name + "-cert"}
+	if err := c.Get(ctx, secretName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(4).InfoS("Certificate secret not created yet, skipping rotation", "karmada", klog.KObj(karmada))
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	threshold := c.threshold(karmada)
+	now := c.now()
+
+	renewedAny := false
+	nearExpiryAny := false
+	requeueAfter := certsutil.Duration365d
+
+	rolledDeployments := map[string]struct{}{}
+	for _, leaf := range componentLeaves {
+		certPEM, ok := secret.Data[leaf.certKey]
+		if !ok {
+			continue
+		}
+		if _, rotating := secret.Data[leaf.caCertKey+"-next"]; rotating {
+			// Its CA is mid-rotation: reconcileCAs below migrates every leaf
+			// under a rotating CA unconditionally, independent of its own
+			// expiry, so handling it here too would just sign it twice.
+			continue
+		}
+		cert, err := parseLeafCert(certPEM)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to parse %s in secret %s: %v", leaf.certKey, secretName, err)
+		}
+
+		remaining, due := nearExpiry(cert, now, threshold)
+		if !due {
+			if remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+			continue
+		}
+
+		var renewErr error
+		if issuer, dnsNames := c.acmeIssuer(karmada, leaf); issuer != nil {
+			renewErr = c.renewLeafViaIssuer(ctx, issuer, secret, leaf, cert, dnsNames)
+		} else {
+			renewErr = c.renewLeaf(secret, leaf, cert, secret.Data[leaf.caCertKey], secret.Data[leaf.caKeyKey])
+		}
+		if err := renewErr; err != nil {
+			nearExpiryAny = true
+			klog.ErrorS(err, "Failed to renew certificate", "karmada", klog.KObj(karmada), "cert", leaf.certKey)
+			c.EventRecorder.Eventf(karmada, corev1.EventTypeWarning, reasonNearExpiry, "failed to renew %s: %v", leaf.certKey, err)
+			continue
+		}
+
+		renewedAny = true
+		c.EventRecorder.Eventf(karmada, corev1.EventTypeNormal, reasonRenewed, "renewed %s", leaf.certKey)
+		for _, d := range leaf.deployments {
+			rolledDeployments[d] = struct{}{}
+		}
+	}
+
+	leavesMigrated, caTransitioned, caRequeueAfter := c.reconcileCAs(ctx, karmada, secret, threshold, now, rolledDeployments)
+	renewedAny = renewedAny || leavesMigrated
+	if caRequeueAfter < requeueAfter {
+		requeueAfter = caRequeueAfter
+	}
+
+	if renewedAny {
+		if err := c.Update(ctx, secret); err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to persist renewed certificates: %v", err)
+		}
+		for name := range rolledDeployments {
+			if err := c.bumpDeployment(ctx, karmada.Namespace, name, secret.ResourceVersion); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	setCondition(karmada, ConditionCertificatesRenewed, renewedAny || caTransitioned)
+	setCondition(karmada, ConditionCertificatesNearExpiry, nearExpiryAny)
+	if err := c.Status().Update(ctx, karmada); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to update status: %v", err)
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileCAs drives the two-phase rotation for every CA GenCerts issues:
+// triggering RotateCA when the active root is near expiry (or rotation is
+// forced), migrating dependent leaves onto the pending "-next" root one
+// reconcile at a time, and calling promoteCA once every leaf has been
+// re-signed against it. RotateCA/promoteCA persist the secret themselves, so
+// only leaf migrations performed directly here (leavesMigrated) need the
+// caller to persist afterward. A problem with any single CA (unparsable
+// material, a failed migration) is logged and retried on the next reconcile
+// rather than aborting the whole pass, so one bad CA can't block the others.
+func (c *RotationController) reconcileCAs(ctx context.Context, karmada *operatorapi.Karmada, secret *corev1.Secret, threshold float64, now time.Time, rolledDeployments map[string]struct{}) (leavesMigrated, caTransitioned bool, requeueAfter time.Duration) {
+	requeueAfter = certsutil.Duration365d
+
+	for _, spec := range caSpecs {
+		nextCertKey, nextKeyKey := spec.certKey+"-next", spec.keyKey+"-next"
+
+		if nextCertPEM, inProgress := secret.Data[nextCertKey]; inProgress {
+			nextCert, err := parseLeafCert(nextCertPEM)
+			if err != nil {
+				klog.ErrorS(err, "Unable to parse pending CA certificate, will retry", "karmada", klog.KObj(karmada), "ca", nextCertKey)
+				if caRotationRequeue < requeueAfter {
+					requeueAfter = caRotationRequeue
+				}
+				continue
+			}
+
+			migrated := true
+			for _, leaf := range leavesForCA(spec.certKey) {
+				leafCertPEM, ok := secret.Data[leaf.certKey]
+				if !ok {
+					// Not deployed for this Karmada instance, so there's
+					// nothing to migrate.
+					continue
+				}
+				leafCert, err := parseLeafCert(leafCertPEM)
+				if err != nil {
+					klog.ErrorS(err, "Unable to parse leaf certificate during CA rotation, will retry", "karmada", klog.KObj(karmada), "cert", leaf.certKey)
+					migrated = false
+					continue
+				}
+				if nextCert.CheckSignature(leafCert.SignatureAlgorithm, leafCert.RawTBSCertificate, leafCert.Signature) == nil {
+					continue
+				}
+				migrated = false
+				if err := c.renewLeaf(secret, leaf, leafCert, secret.Data[nextCertKey], secret.Data[nextKeyKey]); err != nil {
+					klog.ErrorS(err, "Failed to migrate leaf onto rotated CA, will retry", "karmada", klog.KObj(karmada), "cert", leaf.certKey, "ca", spec.certKey)
+					continue
+				}
+				leavesMigrated = true
+				for _, d := range leaf.deployments {
+					rolledDeployments[d] = struct{}{}
+				}
+			}
+
+			if migrated {
+				if err := c.promoteCA(ctx, secret, spec.certKey, spec.keyKey); err != nil {
+					klog.ErrorS(err, "Failed to promote rotated CA, will retry", "karmada", klog.KObj(karmada), "ca", spec.certKey)
+					c.EventRecorder.Eventf(karmada, corev1.EventTypeWarning, reasonNearExpiry, "failed to promote rotated CA %s: %v", spec.certKey, err)
+				} else {
+					caTransitioned = true
+					c.EventRecorder.Eventf(karmada, corev1.EventTypeNormal, reasonRenewed, "promoted rotated CA %s", spec.certKey)
+				}
+			} else {
+				c.EventRecorder.Eventf(karmada, corev1.EventTypeNormal, reasonRenewed, "migrating leaves onto rotated CA %s", spec.certKey)
+			}
+			if caRotationRequeue < requeueAfter {
+				requeueAfter = caRotationRequeue
+			}
+			continue
+		}
+
+		certPEM, ok := secret.Data[spec.certKey]
+		if !ok {
+			continue
+		}
+		caCert, err := parseLeafCert(certPEM)
+		if err != nil {
+			klog.ErrorS(err, "Unable to parse CA certificate, skipping rotation check", "karmada", klog.KObj(karmada), "ca", spec.certKey)
+			continue
+		}
+
+		remaining, due := nearExpiry(caCert, now, threshold)
+		if !due {
+			if remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+			continue
+		}
+
+		if len(secret.Data[spec.keyKey]) == 0 {
+			// No private key for this CA, e.g. a DelegatingCAProvider-backed
+			// CA whose signer lives in an external KMS: the operator cannot
+			// mint a replacement, so leave rotation to whatever manages the
+			// external root.
+			klog.V(2).InfoS("CA is near expiry but its key is not present, skipping operator-driven rotation", "karmada", klog.KObj(karmada), "ca", spec.certKey)
+			continue
+		}
+
+		if err := c.RotateCA(ctx, secret, spec.cn, spec.certKey, spec.keyKey); err != nil {
+			klog.ErrorS(err, "Failed to rotate CA", "karmada", klog.KObj(karmada), "ca", spec.certKey)
+			c.EventRecorder.Eventf(karmada, corev1.EventTypeWarning, reasonNearExpiry, "failed to rotate CA %s: %v", spec.certKey, err)
+			continue
+		}
+		caTransitioned = true
+		c.EventRecorder.Eventf(karmada, corev1.EventTypeNormal, reasonRenewed, "started rotation of CA %s", spec.certKey)
+		if caRotationRequeue < requeueAfter {
+			requeueAfter = caRotationRequeue
+		}
+	}
+
+	return leavesMigrated, caTransitioned, requeueAfter
+}
+
+// nearExpiry reports whether cert has fewer than threshold of its total
+// lifetime remaining, and how long until it crosses that line otherwise.
+func nearExpiry(cert *x509.Certificate, now time.Time, threshold float64) (remainingUntilDue time.Duration, due bool) {
+	remaining := cert.NotAfter.Sub(now)
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	due = remaining <= time.Duration(float64(lifetime)*threshold)
+	return remaining - time.Duration(float64(lifetime)*threshold), due
+}
+
+func (c *RotationController) threshold(karmada *operatorapi.Karmada) float64 {
+	if karmada.Spec.RenewCertificates != nil && *karmada.Spec.RenewCertificates {
+		// A forced rotation request treats every leaf, and every CA still
+		// holding its own private key, as due: this is the "rotate
+		// everything now" escape hatch, not a leaf-only operation.
+		return 1
+	}
+	if c.RenewalThreshold > 0 {
+		return c.RenewalThreshold
+	}
+	return defaultRenewalThreshold
+}
+
+func (c *RotationController) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// renewLeaf re-issues a single leaf certificate in place, reusing the CN,
+// SANs and key algorithm of the certificate it replaces and signing against
+// caCertPEM/caKeyPEM — normally leaf.caCertKey/caKeyKey, or the pending
+// "-next" CA material while a rotation is migrating leaves onto it.
+func (c *RotationController) renewLeaf(secret *corev1.Secret, leaf leafSpec, old *x509.Certificate, caCertPEM, caKeyPEM []byte) error {
+	if len(caCertPEM) == 0 || len(caKeyPEM) == 0 {
+		return fmt.Errorf("CA material %s/%s not found in secret", leaf.caCertKey, leaf.caKeyKey)
+	}
+
+	caCert, err := parseLeafCert(caCertPEM)
+	if err != nil {
+		return fmt.Errorf("unable to parse CA certificate %s: %v", leaf.caCertKey, err)
+	}
+	rawCAKey, err := keyutil.ParsePrivateKeyPEM(caKeyPEM)
+	if err != nil {
+		return fmt.Errorf("unable to parse CA key %s: %v", leaf.caKeyKey, err)
+	}
+	caKey, ok := rawCAKey.(crypto.Signer)
+	if !ok {
+		return fmt.Errorf("CA key %s does not implement crypto.Signer", leaf.caKeyKey)
+	}
+
+	cfg := certsutil.NewCertConfig(old.Subject.CommonName, old.Subject.Organization, certutil.AltNames{
+		DNSNames: old.DNSNames,
+		IPs:      old.IPAddresses,
+	}, nil)
+	cfg.Usages = old.ExtKeyUsage
+	cfg.PublicKeyAlgorithm = old.PublicKeyAlgorithm
+	cert, key, err := certsutil.NewCertAndKey(caCert, caKey, cfg)
+	if err != nil {
+		return err
+	}
+
+	encodedKey, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return err
+	}
+	secret.Data[leaf.certKey] = certsutil.EncodeCertPEM(cert)
+	secret.Data[leaf.keyKey] = encodedKey
+	return nil
+}
+
+// acmeIssuer returns the Issuer to use for leaf and the DNS names it should
+// be issued for, or a nil Issuer to fall back to signing it locally against
+// the CA material already in secret. Only the apiserver leaf may be issued
+// externally: karmada.crt, the etcd leaves and front-proxy-client.crt are
+// only ever dialed by the operator's own components, which trust the
+// operator's CA, not a public ACME root.
+func (c *RotationController) acmeIssuer(karmada *operatorapi.Karmada, leaf leafSpec) (certsutil.Issuer, []string) {
+	if leaf.certKey != "apiserver.crt" {
+		return nil, nil
+	}
+	apiServer := karmada.Spec.APIServer
+	if apiServer == nil || apiServer.ServingCert == nil || apiServer.ServingCert.ACME == nil {
+		return nil, nil
+	}
+	acmeCfg := apiServer.ServingCert.ACME
+	return &acmeissuer.Issuer{
+		Client:                 c.Client,
+		DirectoryURL:           acmeCfg.DirectoryURL,
+		Contact:                acmeCfg.Contact,
+		ChallengeResponder:     c.ACMEChallengeResponder,
+		AccountSecretNamespace: karmada.Namespace,
+		AccountSecretName:      acmeCfg.AccountSecretRef.Name,
+	}, acmeCfg.DNSNames
+}
+
+// renewLeafViaIssuer re-issues a single leaf certificate through issuer
+// instead of signing it locally, reusing the CN/key usages of the
+// certificate it replaces and requesting dnsNames as its SANs — falling back
+// to the certificate being replaced when dnsNames is empty, since an ACME
+// server only ever issues for DNS names, never IPs. Unlike renewLeaf, the
+// replacement's NotAfter is whatever issuer actually granted (e.g. an ACME
+// server's own validity window) rather than the operator's own Duration365d
+// default, so the next reconcile's nearExpiry check tracks the issuer's real
+// expiry.
+func (c *RotationController) renewLeafViaIssuer(ctx context.Context, issuer certsutil.Issuer, secret *corev1.Secret, leaf leafSpec, old *x509.Certificate, dnsNames []string) error {
+	if len(dnsNames) == 0 {
+		dnsNames = old.DNSNames
+	}
+	cfg := certsutil.NewCertConfig(old.Subject.CommonName, old.Subject.Organization, certutil.AltNames{
+		DNSNames: dnsNames,
+	}, nil)
+	cfg.Usages = old.ExtKeyUsage
+	cfg.PublicKeyAlgorithm = old.PublicKeyAlgorithm
+
+	issued, err := issuer.IssueServingCert(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	secret.Data[leaf.certKey] = issued.CertPEM
+	secret.Data[leaf.keyKey] = issued.KeyPEM
+	return nil
+}
+
+// RotateCA performs phase one of a CA rotation: it issues a new CA key pair
+// for caCertKey/caKeyKey, stores it under the "-next" suffix alongside the
+// current one, and bundles both roots under caCertKey so that verifiers
+// trust either root while leaves are migrated. Phase two, promoteCA, is
+// driven by reconcileCAs on a follow-up reconcile once every leaf has been
+// re-signed against the new CA, at which point the old root is dropped from
+// the bundle. This mirrors the old+new root bundling Swarmkit's RootCABundle
+// performs during its own CA rotation.
+func (c *RotationController) RotateCA(ctx context.Context, secret *corev1.Secret, cn, caCertKey, caKeyKey string) error {
+	newCert, newKey, err := certsutil.NewCACertAndKey(cn)
+	if err != nil {
+		return fmt.Errorf("unable to generate replacement CA for %s: %v", caCertKey, err)
+	}
+	encodedKey, err := keyutil.MarshalPrivateKeyToPEM(newKey)
+	if err != nil {
+		return err
+	}
+
+	nextCertKey, nextKeyKey := caCertKey+"-next", caKeyKey+"-next"
+	secret.Data[nextCertKey] = certsutil.EncodeCertPEM(newCert)
+	secret.Data[nextKeyKey] = encodedKey
+
+	// Bundle the new root alongside the current one so leaves signed by
+	// either are trusted while the rotation is in flight.
+	secret.Data[caCertKey] = append(append([]byte{}, secret.Data[caCertKey]...), secret.Data[nextCertKey]...)
+	return c.Update(ctx, secret)
+}
+
+// promoteCA completes phase two of a CA rotation once every leaf under
+// caCertKey has been re-issued from the "-next" CA: it discards the old CA
+// material and promotes the new pair to the canonical keys. Called from
+// reconcileCAs once reconcileCAs has confirmed every dependent leaf verifies
+// against the pending root.
+func (c *RotationController) promoteCA(ctx context.Context, secret *corev1.Secret, caCertKey, caKeyKey string) error {
+	nextCertKey, nextKeyKey := caCertKey+"-next", caKeyKey+"-next"
+	nextCert, ok := secret.Data[nextCertKey]
+	if !ok {
+		return fmt.Errorf("no pending CA rotation found for %s", caCertKey)
+	}
+	nextKey, ok := secret.Data[nextKeyKey]
+	if !ok {
+		return fmt.Errorf("pending CA rotation for %s is missing its key %s", caCertKey, nextKeyKey)
+	}
+	secret.Data[caCertKey] = nextCert
+	secret.Data[caKeyKey] = nextKey
+	delete(secret.Data, nextCertKey)
+	delete(secret.Data, nextKeyKey)
+	return c.Update(ctx, secret)
+}
+
+// bumpDeployment annotates the pod template of the named Deployment with the
+// hash of the certificate Secret's resourceVersion, forcing a rollout.
+func (c *RotationController) bumpDeployment(ctx context.Context, namespace, name, secretResourceVersion string) error {
+	deploy := &appsv1.Deployment{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, deploy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if deploy.Spec.Template.Annotations == nil {
+		deploy.Spec.Template.Annotations = map[string]string{}
+	}
+	deploy.Spec.Template.Annotations[certHashAnnotation] = hashResourceVersion(secretResourceVersion)
+	return c.Update(ctx, deploy)
+}
+
+func hashResourceVersion(rv string) string {
+	sum := sha256.Sum256([]byte(rv))
+	return hex.EncodeToString(sum[:8])
+}
+
+func parseLeafCert(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func setCondition(karmada *operatorapi.Karmada, conditionType string, status bool) {
+	condStatus := metav1.ConditionFalse
+	if status {
+		condStatus = metav1.ConditionTrue
+	}
+	newCondition := metav1.Condition{
+		Type:               conditionType,
+		Status:             condStatus,
+		LastTransitionTime: metav1.Now(),
+		Reason:             conditionType,
+	}
+	for i := range karmada.Status.Conditions {
+		if karmada.Status.Conditions[i].Type == conditionType {
+			if karmada.Status.Conditions[i].Status != condStatus {
+				karmada.Status.Conditions[i] = newCondition
+			}
+			return
+		}
+	}
+	karmada.Status.Conditions = append(karmada.Status.Conditions, newCondition)
+}
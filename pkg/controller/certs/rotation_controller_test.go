@@ -0,0 +1,310 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/keyutil"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorapi "github.com/firefly-io/karmada-operator/pkg/apis/operator/v1alpha1"
+	certsutil "github.com/firefly-io/karmada-operator/pkg/util/certs"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add client-go types to scheme: %v", err)
+	}
+	if err := operatorapi.AddToScheme(scheme); err != nil {
+		t.Fatalf("add operator types to scheme: %v", err)
+	}
+	return scheme
+}
+
+func reconcileRequest(name types.NamespacedName) ctrl.Request {
+	return ctrl.Request{NamespacedName: name}
+}
+
+func TestNearExpiry(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		threshold float64
+		wantDue   bool
+	}{
+		{
+			name:      "well within validity",
+			notBefore: now.Add(-10 * 24 * time.Hour),
+			notAfter:  now.Add(300 * 24 * time.Hour),
+			threshold: 1.0 / 3.0,
+			wantDue:   false,
+		},
+		{
+			name:      "inside the renewal window",
+			notBefore: now.Add(-300 * 24 * time.Hour),
+			notAfter:  now.Add(10 * 24 * time.Hour),
+			threshold: 1.0 / 3.0,
+			wantDue:   true,
+		},
+		{
+			name:      "forced threshold of 1 treats everything as due",
+			notBefore: now.Add(-1 * time.Hour),
+			notAfter:  now.Add(300 * 24 * time.Hour),
+			threshold: 1,
+			wantDue:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := &x509.Certificate{NotBefore: tt.notBefore, NotAfter: tt.notAfter}
+			_, due := nearExpiry(cert, now, tt.threshold)
+			if due != tt.wantDue {
+				t.Errorf("nearExpiry() due = %v, want %v", due, tt.wantDue)
+			}
+		})
+	}
+}
+
+func TestAcmeIssuer(t *testing.T) {
+	controller := &RotationController{}
+	apiserverLeaf := componentLeaves[0]
+	if apiserverLeaf.certKey != "apiserver.crt" {
+		t.Fatalf("componentLeaves[0] = %q, want apiserver.crt", apiserverLeaf.certKey)
+	}
+	karmadaLeaf := componentLeaves[1]
+
+	t.Run("no ServingCert configured", func(t *testing.T) {
+		karmada := &operatorapi.Karmada{}
+		if issuer, _ := controller.acmeIssuer(karmada, apiserverLeaf); issuer != nil {
+			t.Fatalf("acmeIssuer() = %v, want nil", issuer)
+		}
+	})
+
+	t.Run("ACME configured for the apiserver leaf", func(t *testing.T) {
+		karmada := &operatorapi.Karmada{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+			Spec: operatorapi.KarmadaSpec{
+				APIServer: &operatorapi.APIServerSpec{
+					ServingCert: &operatorapi.ServingCertConfig{
+						ACME: &operatorapi.ACMEIssuerConfig{
+							DirectoryURL:     "https://acme.example.com/directory",
+							DNSNames:         []string{"karmada.example.com"},
+							AccountSecretRef: corev1.LocalObjectReference{Name: "acme-account"},
+						},
+					},
+				},
+			},
+		}
+		issuer, dnsNames := controller.acmeIssuer(karmada, apiserverLeaf)
+		if issuer == nil {
+			t.Fatalf("acmeIssuer() = nil, want a non-nil Issuer")
+		}
+		if want := []string{"karmada.example.com"}; !reflect.DeepEqual(dnsNames, want) {
+			t.Fatalf("acmeIssuer() dnsNames = %v, want %v", dnsNames, want)
+		}
+		if issuer, _ := controller.acmeIssuer(karmada, karmadaLeaf); issuer != nil {
+			t.Fatalf("acmeIssuer() for %s = %v, want nil: only the apiserver leaf may be issued externally", karmadaLeaf.certKey, issuer)
+		}
+	})
+}
+
+// genCA returns a self-signed CA certificate/key valid over [notBefore, notAfter].
+func genCA(t *testing.T, cn string, notBefore, notAfter time.Time) (*x509.Certificate, crypto.Signer) {
+	t.Helper()
+	key, err := certsutil.GeneratePrivateKey(x509.ECDSA)
+	if err != nil {
+		t.Fatalf("generate CA key: %v", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		t.Fatalf("generate serial: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatalf("create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse CA certificate: %v", err)
+	}
+	return cert, key
+}
+
+// genLeaf returns a leaf certificate/key signed by caCert/caKey, valid over
+// [notBefore, notAfter].
+func genLeaf(t *testing.T, caCert *x509.Certificate, caKey crypto.Signer, cn string, notBefore, notAfter time.Time) (*x509.Certificate, crypto.Signer) {
+	t.Helper()
+	key, err := certsutil.GeneratePrivateKey(x509.ECDSA)
+	if err != nil {
+		t.Fatalf("generate leaf key: %v", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		t.Fatalf("generate serial: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, key.Public(), caKey)
+	if err != nil {
+		t.Fatalf("create leaf certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse leaf certificate: %v", err)
+	}
+	return cert, key
+}
+
+func encodeKeyPEM(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+	pemBytes, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	return pemBytes
+}
+
+// TestReconcileCAs_TwoPhaseRotation drives a CA from near-expiry through
+// RotateCA, leaf migration, and promoteCA across three reconciles, mirroring
+// Swarmkit's RootCABundle-style old+new root bundling.
+func TestReconcileCAs_TwoPhaseRotation(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	oldCACert, oldCAKey := genCA(t, "karmada", base.Add(-300*24*time.Hour), base.Add(10*24*time.Hour))
+	leafCert, leafKey := genLeaf(t, oldCACert, oldCAKey, "karmada-apiserver", base.Add(-30*24*time.Hour), base.Add(300*24*time.Hour))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "demo-cert"},
+		Data: map[string][]byte{
+			"ca.crt":        certsutil.EncodeCertPEM(oldCACert),
+			"ca.key":        encodeKeyPEM(t, oldCAKey),
+			"apiserver.crt": certsutil.EncodeCertPEM(leafCert),
+			"apiserver.key": encodeKeyPEM(t, leafKey),
+		},
+	}
+	karmada := &operatorapi.Karmada{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "demo"}}
+
+	scheme := newTestScheme(t)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(karmada, secret).WithStatusSubresource(karmada).Build()
+	controller := &RotationController{
+		Client:        c,
+		EventRecorder: record.NewFakeRecorder(32),
+		Now:           func() time.Time { return base },
+	}
+
+	req := types.NamespacedName{Namespace: "test", Name: "demo"}
+	reconcile := func() {
+		t.Helper()
+		if _, err := controller.Reconcile(context.Background(), reconcileRequest(req)); err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+	}
+	fetchSecret := func() *corev1.Secret {
+		t.Helper()
+		got := &corev1.Secret{}
+		if err := c.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "demo-cert"}, got); err != nil {
+			t.Fatalf("get secret: %v", err)
+		}
+		return got
+	}
+
+	// Phase one: the CA is near expiry, so the first reconcile should start
+	// a rotation, bundling the old and new roots under ca.crt.
+	reconcile()
+	phase1 := fetchSecret()
+	if _, ok := phase1.Data["ca.crt-next"]; !ok {
+		t.Fatalf("expected ca.crt-next to be created on phase one, got keys %v", keysOf(phase1.Data))
+	}
+	if !bytes.Contains(phase1.Data["ca.crt"], phase1.Data["ca.crt-next"]) {
+		t.Fatalf("expected ca.crt to bundle the new root alongside the old one")
+	}
+	if !bytes.Equal(phase1.Data["apiserver.crt"], certsutil.EncodeCertPEM(leafCert)) {
+		t.Fatalf("leaf should not be migrated yet on phase one")
+	}
+
+	// Phase two: the next reconcile should migrate the leaf onto the
+	// pending root, but not yet promote it.
+	reconcile()
+	phase2 := fetchSecret()
+	if bytes.Equal(phase2.Data["apiserver.crt"], certsutil.EncodeCertPEM(leafCert)) {
+		t.Fatalf("expected the leaf to be re-signed against the pending CA on phase two")
+	}
+	if _, ok := phase2.Data["ca.crt-next"]; !ok {
+		t.Fatalf("ca.crt-next should still be pending after migrating the only leaf")
+	}
+
+	// Phase three: every leaf now verifies against the pending root, so this
+	// reconcile should promote it and drop the "-next" material.
+	reconcile()
+	phase3 := fetchSecret()
+	if _, ok := phase3.Data["ca.crt-next"]; ok {
+		t.Fatalf("expected ca.crt-next to be promoted away, got keys %v", keysOf(phase3.Data))
+	}
+	if _, ok := phase3.Data["ca.key-next"]; ok {
+		t.Fatalf("expected ca.key-next to be promoted away, got keys %v", keysOf(phase3.Data))
+	}
+	if bytes.Equal(phase3.Data["ca.crt"], phase1.Data["ca.crt"]) {
+		t.Fatalf("expected ca.crt to be replaced by the promoted root, not left bundled")
+	}
+}
+
+func keysOf(data map[string][]byte) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// kubeconfigSecretKey is the Secret data key a member cluster's kubeconfig is
+// expected under, mirroring the "tls.crt"/"tls.key" convention the operator
+// already uses for CA and serving-cert Secrets.
+const kubeconfigSecretKey = "kubeconfig"
+
+// ClusterClientFactory resolves dynamic clients and RESTMappers for member
+// clusters by reading each cluster's kubeconfig from a Secret named
+// "<clusterName>-kubeconfig" in Namespace, one fixed namespace shared by every
+// ResourceRegistry (the operator's --cluster-kubeconfig-secret-namespace).
+type ClusterClientFactory struct {
+	Client    client.Client
+	Namespace string
+}
+
+// NewClusterClientFactory builds a ClusterClientFactory resolving member
+// cluster kubeconfigs out of namespace.
+func NewClusterClientFactory(c client.Client, namespace string) *ClusterClientFactory {
+	return &ClusterClientFactory{Client: c, Namespace: namespace}
+}
+
+// DynamicClientForCluster implements ClusterDynamicClientFunc.
+func (f *ClusterClientFactory) DynamicClientForCluster(clusterName string) (dynamic.Interface, error) {
+	cfg, err := f.restConfigForCluster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return dynamic.NewForConfig(cfg)
+}
+
+// RESTMapperForCluster implements ClusterRESTMapperFunc.
+func (f *ClusterClientFactory) RESTMapperForCluster(clusterName string) (meta.RESTMapper, error) {
+	cfg, err := f.restConfigForCluster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build discovery client for cluster %s: %v", clusterName, err)
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch API group resources for cluster %s: %v", clusterName, err)
+	}
+	return restmapper.NewDiscoveryRESTMapper(groupResources), nil
+}
+
+// restConfigForCluster fetches clusterName's kubeconfig Secret and parses it
+// into a *rest.Config.
+func (f *ClusterClientFactory) restConfigForCluster(clusterName string) (*rest.Config, error) {
+	secretName := clusterName + "-kubeconfig"
+	secret := &corev1.Secret{}
+	if err := f.Client.Get(context.Background(), types.NamespacedName{Namespace: f.Namespace, Name: secretName}, secret); err != nil {
+		return nil, fmt.Errorf("unable to fetch kubeconfig Secret %s/%s for cluster %s: %v", f.Namespace, secretName, clusterName, err)
+	}
+	kubeconfig, ok := secret.Data[kubeconfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig Secret %s/%s is missing required key %q", f.Namespace, secretName, kubeconfigSecretKey)
+	}
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse kubeconfig for cluster %s: %v", clusterName, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,508 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package search implements the search-indexer subsystem: it reconciles
+// ResourceRegistry objects by watching the resources they select on member
+// clusters and upserting them into the configured BackendStore, currently
+// OpenSearch.
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	opensearch "github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	searchapi "github.com/karmada-io/karmada/pkg/apis/search"
+	"github.com/karmada-io/karmada/pkg/util/restmapper"
+)
+
+const (
+	// ConditionIndexReady reports that every ResourceSelector in the
+	// registry has an informer running and its index template created.
+	ConditionIndexReady = "IndexReady"
+
+	resyncPeriod = 10 * time.Minute
+)
+
+// ClusterDynamicClientFunc resolves a dynamic client for a member cluster
+// by name, e.g. by loading its kubeconfig Secret.
+type ClusterDynamicClientFunc func(clusterName string) (dynamic.Interface, error)
+
+// ClusterRESTMapperFunc resolves a discovery-backed RESTMapper for a member
+// cluster by name, used to turn a ResourceSelector's Kind into the REST
+// resource name the dynamic client actually needs.
+type ClusterRESTMapperFunc func(clusterName string) (meta.RESTMapper, error)
+
+// IndexController reconciles ResourceRegistry objects, keeping a dynamic
+// informer running per (member cluster, ResourceSelector) pair and shipping
+// every observed object into the registry's BackendStore.
+type IndexController struct {
+	client.Client
+	DynamicClientForCluster ClusterDynamicClientFunc
+	RESTMapperForCluster    ClusterRESTMapperFunc
+
+	mu      sync.Mutex
+	running map[types.NamespacedName]*registrySync
+}
+
+// registrySync tracks the informers and OpenSearch client running for a
+// single ResourceRegistry so a reconcile can tear them down on spec change.
+type registrySync struct {
+	cancel     context.CancelFunc
+	backend    *openSearchBackend
+	generation int64
+}
+
+// SetupWithManager registers the controller with mgr.
+func (c *IndexController) SetupWithManager(mgr ctrl.Manager) error {
+	c.running = map[types.NamespacedName]*registrySync{}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&searchapi.ResourceRegistry{}).
+		Complete(c)
+}
+
+// Reconcile ensures a sync is running for the named ResourceRegistry,
+// restarting it only when the spec has actually changed since the last
+// reconcile — including the reconcile this controller schedules itself via
+// RequeueAfter — so steady-state ticks just refresh status.
+func (c *IndexController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	registry := &searchapi.ResourceRegistry{}
+	if err := c.Get(ctx, req.NamespacedName, registry); err != nil {
+		if apierrors.IsNotFound(err) {
+			c.stop(req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if registry.Spec.BackendStore == nil || registry.Spec.BackendStore.OpenSearch == nil {
+		return ctrl.Result{}, fmt.Errorf("resourceregistry %s has no OpenSearch backend configured", req.NamespacedName)
+	}
+
+	if sync := c.currentSync(req.NamespacedName); sync != nil && sync.generation == registry.Generation {
+		return c.reportStatus(ctx, registry, sync.backend)
+	}
+
+	backend, err := c.buildBackend(ctx, registry)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to build OpenSearch backend: %v", err)
+	}
+
+	resolved := make(map[string]schema.GroupVersionResource, len(registry.Spec.ResourceSelectors))
+	for _, selector := range registry.Spec.ResourceSelectors {
+		gvr, err := c.resolveSelector(registry, selector)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to resolve %s/%s: %v", selector.APIVersion, selector.Kind, err)
+		}
+		resolved[selectorKey(selector)] = gvr
+		if err := backend.ensureIndexTemplate(ctx, gvr); err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to ensure index template for %s: %v", gvr, err)
+		}
+	}
+
+	// Carry the last synced resourceVersion forward from the sync being
+	// replaced, since the freshly built backend hasn't observed anything yet.
+	if prev := c.currentSync(req.NamespacedName); prev != nil {
+		prev.backend.mu.Lock()
+		backend.lastSyncedResourceVersion = prev.backend.lastSyncedResourceVersion
+		prev.backend.mu.Unlock()
+	}
+
+	c.restart(req.NamespacedName, registry, backend, resolved)
+
+	return c.reportStatus(ctx, registry, backend)
+}
+
+func (c *IndexController) reportStatus(ctx context.Context, registry *searchapi.ResourceRegistry, backend *openSearchBackend) (ctrl.Result, error) {
+	backend.mu.Lock()
+	registry.Status.LastSyncedResourceVersion = backend.lastSyncedResourceVersion
+	backend.mu.Unlock()
+	registry.Status.Conditions = setCondition(registry.Status.Conditions, ConditionIndexReady, metav1.ConditionTrue, "Synced")
+	if err := c.Status().Update(ctx, registry); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to update status: %v", err)
+	}
+	return ctrl.Result{RequeueAfter: resyncPeriod}, nil
+}
+
+// selectorKey identifies a ResourceSelector for the purpose of resolving and
+// caching its GroupVersionResource.
+func selectorKey(selector searchapi.ResourceSelector) string {
+	return selector.APIVersion + "/" + selector.Kind
+}
+
+// resolveSelector maps a ResourceSelector's Kind to the plural, lowercase
+// REST resource name the dynamic client needs, via the first target
+// cluster's discovery-backed RESTMapper. Every dynClient.Resource(gvr) call
+// requires the REST resource name, not the CRD Kind.
+func (c *IndexController) resolveSelector(registry *searchapi.ResourceRegistry, selector searchapi.ResourceSelector) (schema.GroupVersionResource, error) {
+	clusterNames := registry.Spec.TargetCluster.ClusterNames
+	if len(clusterNames) == 0 {
+		return schema.GroupVersionResource{}, fmt.Errorf("resourceregistry %s/%s targets no clusters", registry.Namespace, registry.Name)
+	}
+
+	gv, err := schema.ParseGroupVersion(selector.APIVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid apiVersion %q: %v", selector.APIVersion, err)
+	}
+
+	mapper, err := c.RESTMapperForCluster(clusterNames[0])
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("unable to build RESTMapper for cluster %s: %v", clusterNames[0], err)
+	}
+
+	return restmapper.GetGroupVersionResource(mapper, gv.WithKind(selector.Kind))
+}
+
+func (c *IndexController) currentSync(name types.NamespacedName) *registrySync {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.running[name]
+}
+
+// stop cancels the running sync's informers and flushes/closes its bulk
+// indexer so reconciles don't leak a fresh set of workers and HTTP
+// connections on every spec change.
+func (c *IndexController) stop(name types.NamespacedName) {
+	c.mu.Lock()
+	sync, ok := c.running[name]
+	if ok {
+		delete(c.running, name)
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	sync.cancel()
+	closeCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := sync.backend.indexer.Close(closeCtx); err != nil {
+		klog.ErrorS(err, "Unable to cleanly close OpenSearch bulk indexer", "resourceregistry", name)
+	}
+}
+
+// restart tears down any previous sync for the registry and starts a fresh
+// one against the newly observed generation.
+func (c *IndexController) restart(name types.NamespacedName, registry *searchapi.ResourceRegistry, backend *openSearchBackend, resolved map[string]schema.GroupVersionResource) {
+	c.stop(name)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.mu.Lock()
+	c.running[name] = &registrySync{cancel: cancel, backend: backend, generation: registry.Generation}
+	c.mu.Unlock()
+
+	for _, selector := range registry.Spec.ResourceSelectors {
+		selector := selector
+		gvr := resolved[selectorKey(selector)]
+		go c.watchSelector(ctx, registry, selector, gvr, backend)
+	}
+}
+
+// watchSelector runs a dynamic informer against every cluster targeted by
+// registry for the given selector, upserting or deleting documents as
+// objects change, until ctx is cancelled.
+func (c *IndexController) watchSelector(ctx context.Context, registry *searchapi.ResourceRegistry, selector searchapi.ResourceSelector, gvr schema.GroupVersionResource, backend *openSearchBackend) {
+	for _, clusterName := range registry.Spec.TargetCluster.ClusterNames {
+		dynClient, err := c.DynamicClientForCluster(clusterName)
+		if err != nil {
+			klog.ErrorS(err, "Unable to build dynamic client for cluster", "cluster", clusterName)
+			continue
+		}
+
+		factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynClient, resyncPeriod, selector.Namespace, nil)
+		informer := factory.ForResource(gvr).Informer()
+		_, _ = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				backend.upsert(ctx, clusterName, gvr, obj.(*unstructured.Unstructured))
+			},
+			UpdateFunc: func(_, obj interface{}) {
+				backend.upsert(ctx, clusterName, gvr, obj.(*unstructured.Unstructured))
+			},
+			DeleteFunc: func(obj interface{}) {
+				if u, ok := obj.(*unstructured.Unstructured); ok {
+					backend.delete(ctx, clusterName, gvr, u)
+				}
+			},
+		})
+
+		go informer.Run(ctx.Done())
+		go c.resyncDeletions(ctx, dynClient, clusterName, selector, gvr, backend)
+	}
+}
+
+// resyncDeletions periodically lists every live object for gvr on cluster
+// and deletes any indexed document whose id is no longer present, catching
+// deletes an informer can miss across a restart.
+func (c *IndexController) resyncDeletions(ctx context.Context, dynClient dynamic.Interface, cluster string, selector searchapi.ResourceSelector, gvr schema.GroupVersionResource, backend *openSearchBackend) {
+	ticker := time.NewTicker(resyncPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			live, err := dynClient.Resource(gvr).Namespace(selector.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				klog.ErrorS(err, "Unable to list live objects for resync", "cluster", cluster, "gvr", gvr)
+				continue
+			}
+			liveIDs := make(map[string]struct{}, len(live.Items))
+			for i := range live.Items {
+				liveIDs[docID(cluster, &live.Items[i])] = struct{}{}
+			}
+
+			indexedIDs, err := backend.listDocIDs(ctx, cluster, gvr)
+			if err != nil {
+				klog.ErrorS(err, "Unable to list indexed documents for resync", "cluster", cluster, "gvr", gvr)
+				continue
+			}
+			for _, id := range indexedIDs {
+				if _, ok := liveIDs[id]; !ok {
+					_ = backend.indexer.Add(ctx, opensearchutil.BulkIndexerItem{Action: "delete", Index: indexName(gvr), DocumentID: id})
+				}
+			}
+		}
+	}
+}
+
+func setCondition(conditions []metav1.Condition, condType string, status metav1.ConditionStatus, reason string) []metav1.Condition {
+	now := metav1.Now()
+	for i := range conditions {
+		if conditions[i].Type == condType {
+			if conditions[i].Status != status {
+				conditions[i].Status = status
+				conditions[i].LastTransitionTime = now
+				conditions[i].Reason = reason
+			}
+			return conditions
+		}
+	}
+	return append(conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		LastTransitionTime: now,
+	})
+}
+
+// openSearchBackend batches documents destined for OpenSearch and flushes
+// them through a bulk indexer with exponential backoff on 429s.
+type openSearchBackend struct {
+	client  *opensearch.Client
+	indexer opensearchutil.BulkIndexer
+
+	mu                        sync.Mutex
+	lastSyncedResourceVersion string
+}
+
+// retryBackoff doubles the delay with each attempt (100ms, 200ms, 400ms, ...),
+// capped at 5s, so a sustained burst of 429s backs off instead of hammering
+// OpenSearch at a fixed interval.
+func retryBackoff(attempt int) time.Duration {
+	backoff := 100 * time.Millisecond * time.Duration(math.Pow(2, float64(attempt-1)))
+	if backoff > 5*time.Second {
+		return 5 * time.Second
+	}
+	return backoff
+}
+
+func (c *IndexController) buildBackend(ctx context.Context, registry *searchapi.ResourceRegistry) (*openSearchBackend, error) {
+	cfg := registry.Spec.BackendStore.OpenSearch
+
+	osCfg := opensearch.Config{
+		Addresses:     cfg.Addresses,
+		RetryOnStatus: []int{http.StatusTooManyRequests},
+		MaxRetries:    5,
+		RetryBackoff:  retryBackoff,
+	}
+	if cfg.SecretRef.Name != "" {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: registry.Namespace, Name: cfg.SecretRef.Name}, secret); err != nil {
+			return nil, err
+		}
+		osCfg.Username = string(secret.Data["username"])
+		osCfg.Password = string(secret.Data["password"])
+	}
+
+	osClient, err := opensearch.NewClient(osCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	indexer, err := opensearchutil.NewBulkIndexer(opensearchutil.BulkIndexerConfig{
+		Client:        osClient,
+		NumWorkers:    2,
+		FlushBytes:    5 << 20,
+		FlushInterval: 5 * time.Second,
+		OnError: func(_ context.Context, err error) {
+			klog.ErrorS(err, "Bulk indexer error")
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &openSearchBackend{client: osClient, indexer: indexer}, nil
+}
+
+// docID mirrors the "cluster/namespace/name" scheme the search-indexer uses
+// across every GVR's index.
+func docID(cluster string, obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s/%s", cluster, obj.GetNamespace(), obj.GetName())
+}
+
+func indexName(gvr schema.GroupVersionResource) string {
+	if gvr.Group == "" {
+		return fmt.Sprintf("karmada.%s.%s", gvr.Version, gvr.Resource)
+	}
+	return fmt.Sprintf("karmada.%s.%s.%s", gvr.Group, gvr.Version, gvr.Resource)
+}
+
+func (b *openSearchBackend) ensureIndexTemplate(ctx context.Context, gvr schema.GroupVersionResource) error {
+	template := map[string]interface{}{
+		"index_patterns": []string{indexName(gvr) + "*"},
+		"template": map[string]interface{}{
+			"mappings": map[string]interface{}{
+				"properties": map[string]interface{}{
+					"metadata.labels":      map[string]interface{}{"type": "flattened"},
+					"metadata.annotations": map[string]interface{}{"type": "flattened"},
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+
+	res, err := b.client.Indices.PutIndexTemplate(indexName(gvr)+"-template", bytes.NewReader(body), b.client.Indices.PutIndexTemplate.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("opensearch rejected index template: %s", res.String())
+	}
+	return nil
+}
+
+// upsert indexes obj's full object plus karmada.cluster/karmada.resourceVersion
+// metadata under doc id "cluster/namespace/name".
+func (b *openSearchBackend) upsert(ctx context.Context, cluster string, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) {
+	source := runtime.DeepCopyJSON(obj.Object)
+	source["karmada.cluster"] = cluster
+	source["karmada.resourceVersion"] = obj.GetResourceVersion()
+
+	b.mu.Lock()
+	b.lastSyncedResourceVersion = obj.GetResourceVersion()
+	b.mu.Unlock()
+
+	body, err := json.Marshal(source)
+	if err != nil {
+		klog.ErrorS(err, "Unable to marshal object for indexing", "cluster", cluster, "object", klog.KObj(obj))
+		return
+	}
+
+	_ = b.indexer.Add(ctx, opensearchutil.BulkIndexerItem{
+		Action:     "index",
+		Index:      indexName(gvr),
+		DocumentID: docID(cluster, obj),
+		Body:       bytes.NewReader(body),
+		OnFailure: func(_ context.Context, item opensearchutil.BulkIndexerItem, res opensearchutil.BulkIndexerResponseItem, err error) {
+			klog.ErrorS(err, "Failed to index document", "id", item.DocumentID, "status", res.Status)
+		},
+	})
+}
+
+// listDocIDs returns the ids of documents indexed for cluster under gvr's
+// index, using a single scroll-free search restricted to _id. The search
+// returns at most 10000 hits and is not paged, so in a larger index the ids
+// beyond the first 10000 are not returned and resync does not see them.
+func (b *openSearchBackend) listDocIDs(ctx context.Context, cluster string, gvr schema.GroupVersionResource) ([]string, error) {
+	query := map[string]interface{}{
+		"query":   map[string]interface{}{"term": map[string]interface{}{"karmada.cluster": cluster}},
+		"_source": false,
+		"size":    10000,
+	}
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := b.client.Search(
+		b.client.Search.WithContext(ctx),
+		b.client.Search.WithIndex(indexName(gvr)),
+		b.client.Search.WithBody(bytes.NewReader(body)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, fmt.Errorf("opensearch search failed: %s", res.String())
+	}
+
+	var parsed struct {
+		Hits struct {
+			Hits []struct {
+				ID string `json:"_id"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(parsed.Hits.Hits))
+	for _, hit := range parsed.Hits.Hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids, nil
+}
+
+func (b *openSearchBackend) delete(ctx context.Context, cluster string, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) {
+	_ = b.indexer.Add(ctx, opensearchutil.BulkIndexerItem{
+		Action:     "delete",
+		Index:      indexName(gvr),
+		DocumentID: docID(cluster, obj),
+		OnFailure: func(_ context.Context, item opensearchutil.BulkIndexerItem, res opensearchutil.BulkIndexerResponseItem, err error) {
+			klog.ErrorS(err, "Failed to delete document", "id", item.DocumentID, "status", res.Status)
+		},
+	})
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	searchapi "github.com/karmada-io/karmada/pkg/apis/search"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 100 * time.Millisecond},
+		{attempt: 2, want: 200 * time.Millisecond},
+		{attempt: 3, want: 400 * time.Millisecond},
+		{attempt: 10, want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := retryBackoff(tt.attempt); got != tt.want {
+			t.Errorf("retryBackoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func newIndexerTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add client-go types to scheme: %v", err)
+	}
+	if err := searchapi.AddToScheme(scheme); err != nil {
+		t.Fatalf("add search types to scheme: %v", err)
+	}
+	return scheme
+}
+
+// TestReconcile drives a ResourceRegistry with no ResourceSelectors (so no
+// member-cluster dynamic client or RESTMapper is ever needed) through a
+// first reconcile that builds its OpenSearch backend and a second,
+// steady-state reconcile at the same generation that must short-circuit via
+// currentSync instead of rebuilding it.
+func TestReconcile(t *testing.T) {
+	registry := &searchapi.ResourceRegistry{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "demo"},
+		Spec: searchapi.ResourceRegistrySpec{
+			BackendStore: &searchapi.BackendStoreConfig{
+				OpenSearch: &searchapi.OpenSearchConfig{
+					Addresses: []string{"https://opensearch.example.invalid:9200"},
+				},
+			},
+		},
+	}
+
+	scheme := newIndexerTestScheme(t)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(registry).WithStatusSubresource(registry).Build()
+	controller := &IndexController{Client: c}
+	controller.running = map[types.NamespacedName]*registrySync{}
+
+	req := types.NamespacedName{Namespace: "test", Name: "demo"}
+	reconcile := func() ctrl.Result {
+		t.Helper()
+		result, err := controller.Reconcile(context.Background(), ctrl.Request{NamespacedName: req})
+		if err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+		return result
+	}
+
+	reconcile()
+	sync := controller.currentSync(req)
+	if sync == nil {
+		t.Fatalf("expected a sync to be running after the first reconcile")
+	}
+	firstBackend := sync.backend
+
+	// A steady-state reconcile at the same generation must reuse the
+	// existing sync rather than tearing it down and rebuilding it.
+	reconcile()
+	if got := controller.currentSync(req); got == nil || got.backend != firstBackend {
+		t.Fatalf("expected the steady-state reconcile to keep the same backend, got %v", got)
+	}
+
+	got := &searchapi.ResourceRegistry{}
+	if err := c.Get(context.Background(), req, got); err != nil {
+		t.Fatalf("get resourceregistry: %v", err)
+	}
+	found := false
+	for _, cond := range got.Status.Conditions {
+		if cond.Type == ConditionIndexReady && cond.Status == metav1.ConditionTrue {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected %s condition to be True, got %v", ConditionIndexReady, got.Status.Conditions)
+	}
+
+	// Deleting the ResourceRegistry must stop the running sync instead of
+	// erroring.
+	if err := c.Delete(context.Background(), registry); err != nil {
+		t.Fatalf("delete resourceregistry: %v", err)
+	}
+	if _, err := controller.Reconcile(context.Background(), ctrl.Request{NamespacedName: req}); err != nil {
+		t.Fatalf("Reconcile after delete: %v", err)
+	}
+	if controller.currentSync(req) != nil {
+		t.Fatalf("expected the sync to be stopped after the ResourceRegistry was deleted")
+	}
+}
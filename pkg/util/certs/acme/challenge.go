@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// http01Prefix is the well-known path an ACME http-01 challenge response is
+// expected to be served at, followed by the challenge token.
+const http01Prefix = "/.well-known/acme-challenge/"
+
+// ChallengeResponder serves ACME http-01 challenge key authorizations over
+// HTTP so an Issuer can complete authorizations without an external
+// DNSProvider. It is registered with the manager alongside karmada-apiserver's
+// public listener; the ACME server reaches it directly, so that listener
+// must be internet-routable for http-01 to succeed.
+type ChallengeResponder struct {
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+// NewChallengeResponder returns a ChallengeResponder ready to serve.
+func NewChallengeResponder() *ChallengeResponder {
+	return &ChallengeResponder{tokens: map[string]string{}}
+}
+
+// Put records the key authorization an http-01 challenge for token expects
+// to be served back.
+func (r *ChallengeResponder) Put(token, keyAuth string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[token] = keyAuth
+}
+
+// Remove forgets token, once its authorization has been accepted or the
+// attempt has failed.
+func (r *ChallengeResponder) Remove(token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tokens, token)
+}
+
+// ServeHTTP implements http.Handler, serving the key authorization for
+// requests under /.well-known/acme-challenge/<token>.
+func (r *ChallengeResponder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	token := strings.TrimPrefix(req.URL.Path, http01Prefix)
+
+	r.mu.Lock()
+	keyAuth, ok := r.tokens[token]
+	r.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(keyAuth))
+}
+
+// Runnable adapts ChallengeResponder into a controller-runtime
+// manager.Runnable, serving http-01 responses over plain HTTP for as long as
+// the manager keeps running. It must be reachable at the well-known path on
+// whatever host the ACME order's DNS names resolve to.
+type Runnable struct {
+	Responder *ChallengeResponder
+	Addr      string
+}
+
+// Start implements manager.Runnable.
+func (r *Runnable) Start(ctx context.Context) error {
+	httpServer := &http.Server{
+		Addr:    r.Addr,
+		Handler: r.Responder,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		if err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: the
+// responder listens on every operator replica, not just the leader. Tokens
+// are held in the memory of the replica that Put them, so a validation
+// request reaching any other replica gets a 404.
+func (r *Runnable) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package acme implements a certs.Issuer that provisions certificates
+// through an ACME v2 directory (Let's Encrypt or an internal ACME server)
+// instead of signing them locally against the operator's own CA.
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+
+	xacme "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/keyutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	certsutil "github.com/firefly-io/karmada-operator/pkg/util/certs"
+)
+
+// DNSProvider provisions and cleans up the TXT record looked up by an
+// ACME DNS-01 challenge for a domain.
+type DNSProvider interface {
+	Present(ctx context.Context, domain, keyAuthDigest string) error
+	CleanUp(ctx context.Context, domain, keyAuthDigest string) error
+}
+
+// Issuer implements certs.Issuer by obtaining certificates from an ACME
+// directory. It persists the ACME account key in a Kubernetes Secret so the
+// account is reused across reconciles and operator restarts.
+type Issuer struct {
+	Client       client.Client
+	DirectoryURL string
+	Contact      []string
+
+	// DNS, when set, is used to satisfy dns-01 challenges. When nil, only
+	// http-01 challenges are attempted.
+	DNS DNSProvider
+
+	// ChallengeResponder, when set, is used to satisfy http-01 challenges by
+	// serving their key authorization over HTTP. Required unless DNS is set.
+	ChallengeResponder *ChallengeResponder
+
+	AccountSecretNamespace string
+	AccountSecretName      string
+}
+
+var _ certsutil.Issuer = &Issuer{}
+
+// IssueServingCert implements certs.Issuer.
+func (i *Issuer) IssueServingCert(ctx context.Context, cfg *certsutil.CertsConfig) (*certsutil.IssuedCert, error) {
+	if len(cfg.AltNames.DNSNames) == 0 {
+		return nil, fmt.Errorf("ACME issuance requires at least one DNS SAN")
+	}
+
+	accountKey, err := i.loadOrCreateAccountKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load ACME account key: %v", err)
+	}
+
+	acmeClient := &xacme.Client{Key: accountKey, DirectoryURL: i.DirectoryURL}
+	if _, err := acmeClient.Register(ctx, &xacme.Account{Contact: i.Contact}, xacme.AcceptTOS); err != nil && err != xacme.ErrAccountAlreadyExists {
+		return nil, fmt.Errorf("unable to register ACME account: %v", err)
+	}
+
+	ids := xacme.DomainIDs(cfg.AltNames.DNSNames...)
+	order, err := acmeClient.AuthorizeOrder(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create ACME order: %v", err)
+	}
+
+	for _, authzURL := range order.AuthzURLs {
+		if err := i.authorize(ctx, acmeClient, authzURL); err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := certsutil.GeneratePrivateKey(cfg.PublicKeyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: cfg.CommonName, Organization: cfg.Organization},
+		DNSNames: cfg.AltNames.DNSNames,
+	}, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create CSR: %v", err)
+	}
+
+	der, _, err := acmeClient.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("unable to finalize ACME order: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der[0])
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certsutil.IssuedCert{
+		CertPEM:  certsutil.EncodeCertPEM(cert),
+		KeyPEM:   keyPEM,
+		NotAfter: cert.NotAfter,
+	}, nil
+}
+
+// authorize drives a single authorization through whichever challenge type
+// is available, preferring dns-01 when a DNSProvider is configured.
+func (i *Issuer) authorize(ctx context.Context, acmeClient *xacme.Client, authzURL string) error {
+	authz, err := acmeClient.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return fmt.Errorf("unable to fetch ACME authorization: %v", err)
+	}
+	if authz.Status == xacme.StatusValid {
+		return nil
+	}
+
+	chal, err := i.pickChallenge(authz)
+	if err != nil {
+		return err
+	}
+
+	domain := authz.Identifier.Value
+	switch chal.Type {
+	case "dns-01":
+		digest, err := acmeClient.DNS01ChallengeRecord(chal.Token)
+		if err != nil {
+			return err
+		}
+		if err := i.DNS.Present(ctx, domain, digest); err != nil {
+			return fmt.Errorf("unable to present dns-01 challenge for %s: %v", domain, err)
+		}
+		defer func() { _ = i.DNS.CleanUp(ctx, domain, digest) }()
+	case "http-01":
+		if i.ChallengeResponder == nil {
+			return fmt.Errorf("http-01 challenge offered for %s but no ChallengeResponder is configured", domain)
+		}
+		keyAuth, err := acmeClient.HTTP01ChallengeResponse(chal.Token)
+		if err != nil {
+			return fmt.Errorf("unable to compute http-01 key authorization for %s: %v", domain, err)
+		}
+		i.ChallengeResponder.Put(chal.Token, keyAuth)
+		defer i.ChallengeResponder.Remove(chal.Token)
+	default:
+		return fmt.Errorf("no usable challenge type offered for %s", domain)
+	}
+
+	if _, err := acmeClient.Accept(ctx, chal); err != nil {
+		return fmt.Errorf("unable to accept %s challenge for %s: %v", chal.Type, domain, err)
+	}
+	if _, err := acmeClient.WaitAuthorization(ctx, authzURL); err != nil {
+		return fmt.Errorf("authorization for %s did not become valid: %v", domain, err)
+	}
+	return nil
+}
+
+func (i *Issuer) pickChallenge(authz *xacme.Authorization) (*xacme.Challenge, error) {
+	var http01 *xacme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "dns-01" && i.DNS != nil {
+			return c, nil
+		}
+		if c.Type == "http-01" {
+			http01 = c
+		}
+	}
+	if http01 != nil {
+		return http01, nil
+	}
+	return nil, fmt.Errorf("no supported challenge type offered for %s", authz.Identifier.Value)
+}
+
+// accountKeySecretKey is the Secret data key the ACME account's private key
+// is persisted under.
+const accountKeySecretKey = "acme-account.key"
+
+// loadOrCreateAccountKey fetches the ACME account key from
+// AccountSecretNamespace/AccountSecretName, generating and persisting one on
+// first use.
+func (i *Issuer) loadOrCreateAccountKey(ctx context.Context) (crypto.Signer, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: i.AccountSecretNamespace, Name: i.AccountSecretName}
+	getErr := i.Client.Get(ctx, key, secret)
+	if getErr == nil {
+		if keyPEM, ok := secret.Data[accountKeySecretKey]; ok {
+			rawKey, err := keyutil.ParsePrivateKeyPEM(keyPEM)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse ACME account key: %v", err)
+			}
+			signer, ok := rawKey.(crypto.Signer)
+			if !ok {
+				return nil, fmt.Errorf("ACME account key does not implement crypto.Signer")
+			}
+			return signer, nil
+		}
+	} else if !apierrors.IsNotFound(getErr) {
+		return nil, getErr
+	}
+
+	accountKey, err := certsutil.GeneratePrivateKey(x509.ECDSA)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(accountKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if apierrors.IsNotFound(getErr) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: i.AccountSecretNamespace, Name: i.AccountSecretName},
+			Data:       map[string][]byte{accountKeySecretKey: keyPEM},
+		}
+		if err := i.Client.Create(ctx, secret); err != nil {
+			return nil, err
+		}
+		return accountKey, nil
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[accountKeySecretKey] = keyPEM
+	if err := i.Client.Update(ctx, secret); err != nil {
+		return nil, err
+	}
+	return accountKey, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/util/keyutil"
+)
+
+// IssuedCert is a PEM-encoded certificate/key pair and the expiry the
+// issuer actually granted, which may not match what was requested (e.g. an
+// ACME server's own NotAfter).
+type IssuedCert struct {
+	CertPEM  []byte
+	KeyPEM   []byte
+	NotAfter time.Time
+}
+
+// Issuer provisions a serving certificate for cfg. Unlike CAProvider, which
+// supplies a CA to sign leaves against locally, an Issuer may reach an
+// external authority (e.g. an ACME server) to obtain the leaf itself.
+type Issuer interface {
+	IssueServingCert(ctx context.Context, cfg *CertsConfig) (*IssuedCert, error)
+}
+
+// LocalCAIssuer issues serving certificates by signing them against a
+// CAProvider, i.e. GenCerts' historical behavior, wrapped so it can be used
+// anywhere an Issuer is expected.
+type LocalCAIssuer struct {
+	CA     CAProvider
+	CAName string
+}
+
+// IssueServingCert implements Issuer.
+func (i *LocalCAIssuer) IssueServingCert(_ context.Context, cfg *CertsConfig) (*IssuedCert, error) {
+	caCert, caKey, err := i.CA.CertAndKey(i.CAName)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, key, err := NewCertAndKey(caCert, caKey, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IssuedCert{
+		CertPEM:  EncodeCertPEM(cert),
+		KeyPEM:   keyPEM,
+		NotAfter: cert.NotAfter,
+	}, nil
+}
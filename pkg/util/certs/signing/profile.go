@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signing implements a small CFSSL/Fabric-CA-style CSR signing
+// subsystem: named profiles constrain what a CSR may be signed into, and a
+// Policy maps profile names to profiles so callers select intent ("I need a
+// member-cluster client cert") rather than raw x509 parameters.
+package signing
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"regexp"
+	"time"
+)
+
+// SigningProfile constrains what Sign will issue for CSRs submitted under
+// its name: the key usages granted, the maximum validity period, the SAN
+// patterns a CSR is permitted to request, and an optional CN template.
+type SigningProfile struct {
+	// Usages are the ExtKeyUsages stamped onto every certificate issued
+	// under this profile.
+	Usages []x509.ExtKeyUsage
+	// MaxValidity bounds how far in the future NotAfter may be set,
+	// regardless of what the CSR or caller requests.
+	MaxValidity time.Duration
+	// AllowedDNSPatterns is a list of regular expressions; every DNSName in
+	// the CSR must match at least one of them. A nil/empty list permits no
+	// DNS SANs.
+	AllowedDNSPatterns []string
+	// AllowedIPRanges is a list of CIDRs; every IPAddress in the CSR must
+	// fall inside at least one of them. A nil/empty list permits no IP
+	// SANs, so a profile has to opt in to them explicitly.
+	AllowedIPRanges []string
+	// CNTemplate, if non-empty, replaces the CSR's CommonName. Sign uses
+	// the string verbatim; it is not rendered as a template.
+	CNTemplate string
+}
+
+// Policy maps profile names to the SigningProfile that governs them.
+type Policy struct {
+	Profiles map[string]*SigningProfile
+}
+
+// DefaultPolicy returns the Policy shipped with the operator, covering the
+// profiles karmadactl register and the karmada-agent rely on.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Profiles: map[string]*SigningProfile{
+			ProfileKarmadaAgent: {
+				Usages:             []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+				MaxValidity:        24 * time.Hour * 365,
+				AllowedDNSPatterns: []string{`^system:node:karmada-agent:.+$`},
+			},
+			ProfileMemberClusterClient: {
+				Usages:             []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+				MaxValidity:        24 * time.Hour * 90,
+				AllowedDNSPatterns: []string{`^[a-z0-9-]+\.member\.karmada\.io$`},
+			},
+			ProfileComponentServing: {
+				Usages:             []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+				MaxValidity:        24 * time.Hour * 365,
+				AllowedDNSPatterns: []string{`^[a-z0-9-]+\.[a-z0-9-]+\.svc(\.cluster\.local)?$`},
+			},
+		},
+	}
+}
+
+// Profile names recognized by DefaultPolicy.
+const (
+	ProfileKarmadaAgent        = "karmada-agent"
+	ProfileMemberClusterClient = "member-cluster-client"
+	ProfileComponentServing    = "component-serving"
+)
+
+// Profile looks up a named profile, returning an error that is safe to
+// surface to API callers.
+func (p *Policy) Profile(name string) (*SigningProfile, error) {
+	profile, ok := p.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing profile %q", name)
+	}
+	return profile, nil
+}
+
+// validateSANs checks that every DNS name in names matches at least one of
+// the profile's AllowedDNSPatterns.
+func (p *SigningProfile) validateSANs(names []string) error {
+	for _, name := range names {
+		if !p.dnsNameAllowed(name) {
+			return fmt.Errorf("SAN %q is not permitted by this profile", name)
+		}
+	}
+	return nil
+}
+
+func (p *SigningProfile) dnsNameAllowed(name string) bool {
+	for _, pattern := range p.AllowedDNSPatterns {
+		matched, err := regexp.MatchString(pattern, name)
+		if err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// validateIPSANs checks that every IP address in ips falls inside at least
+// one of the profile's AllowedIPRanges. Without this, Sign would otherwise
+// carry csr.IPAddresses straight into the issued certificate, letting any
+// mTLS-authenticated caller request an arbitrary IP SAN under any profile.
+func (p *SigningProfile) validateIPSANs(ips []net.IP) error {
+	for _, ip := range ips {
+		if !p.ipAllowed(ip) {
+			return fmt.Errorf("IP SAN %q is not permitted by this profile", ip)
+		}
+	}
+	return nil
+}
+
+func (p *SigningProfile) ipAllowed(ip net.IP) bool {
+	for _, cidr := range p.AllowedIPRanges {
+		_, network, err := net.ParseCIDR(cidr)
+		if err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
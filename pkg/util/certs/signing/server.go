@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signing
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Server exposes Signer over HTTPS so karmadactl register and the
+// karmada-agent can obtain member-cluster and component certificates on
+// demand instead of through GenCerts' all-or-nothing bundle.
+type Server struct {
+	Signer *Signer
+}
+
+// signRequest is the request body for POST /sign.
+type signRequest struct {
+	CSR     string `json:"csr"`
+	Profile string `json:"profile"`
+}
+
+// signResponse is the response body for POST /sign.
+type signResponse struct {
+	Certificate string `json:"certificate,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ServeHTTP implements http.Handler, handling POST /sign requests.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "unable to read request body", http.StatusBadRequest)
+		return
+	}
+
+	var req signRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSON(w, http.StatusBadRequest, signResponse{Error: "malformed request body"})
+		return
+	}
+
+	certPEM, err := s.Signer.Sign(r.Context(), []byte(req.CSR), req.Profile)
+	if err != nil {
+		klog.ErrorS(err, "CSR signing request denied", "profile", req.Profile)
+		writeJSON(w, http.StatusBadRequest, signResponse{Error: err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, signResponse{Certificate: string(certPEM)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, resp signResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// NewMTLSConfig builds a *tls.Config that serves serverCert and only accepts
+// client certificates chaining up to clientCAPool — i.e. the operator-managed
+// Karmada CA — so only callers holding a certificate issued from that CA,
+// such as karmadactl register and the karmada-agent, can reach the signing
+// endpoint.
+func NewMTLSConfig(serverCert tls.Certificate, clientCAPool *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAPool,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// Runnable adapts Server into a controller-runtime manager.Runnable, serving
+// CSR signing requests over mTLS for as long as the manager keeps running.
+type Runnable struct {
+	Server    *Server
+	Addr      string
+	TLSConfig *tls.Config
+}
+
+// Start implements manager.Runnable.
+func (r *Runnable) Start(ctx context.Context) error {
+	httpServer := &http.Server{
+		Addr:      r.Addr,
+		Handler:   r.Server,
+		TLSConfig: r.TLSConfig,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServeTLS("", "")
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		if err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: every
+// operator replica must serve signing requests, not just the leader.
+func (r *Runnable) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	certsutil "github.com/firefly-io/karmada-operator/pkg/util/certs"
+)
+
+const (
+	csrBlockType = "CERTIFICATE REQUEST"
+)
+
+// Signer signs PEM-encoded PKCS#10 CSRs against the Karmada root CA managed
+// by the operator, constrained by a Policy of named SigningProfiles. It is
+// modeled on CFSSL's local signer: the caller picks a profile by name, and
+// the profile — not the CSR — decides what is actually issued.
+type Signer struct {
+	// CA provides the root CA certificate and signer certs are issued from.
+	CA certsutil.CAProvider
+	// CAName is the name CA is asked for, e.g. "karmada".
+	CAName string
+	// Policy maps profile names to SigningProfiles. Defaults to
+	// DefaultPolicy() when nil.
+	Policy *Policy
+	// SerialStore records issued serials for revocation-list generation.
+	// Optional; issued serials are not persisted when nil.
+	SerialStore *SerialStore
+	// Now allows tests to control the clock.
+	Now func() time.Time
+}
+
+// Sign parses csrPEM, validates it against the named profile, and returns a
+// PEM-encoded certificate signed by the operator's CA.
+func (s *Signer) Sign(ctx context.Context, csrPEM []byte, profileName string) ([]byte, error) {
+	policy := s.Policy
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+	profile, err := policy.Profile(profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := parseCSR(csrPEM)
+	if err != nil {
+		return nil, err
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("CSR signature does not verify: %v", err)
+	}
+	if err := profile.validateSANs(csr.DNSNames); err != nil {
+		return nil, err
+	}
+	if err := profile.validateIPSANs(csr.IPAddresses); err != nil {
+		return nil, err
+	}
+
+	caCert, caKey, err := s.CA.CertAndKey(s.CAName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load signing CA %q: %v", s.CAName, err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+
+	cn := csr.Subject.CommonName
+	if profile.CNTemplate != "" {
+		cn = profile.CNTemplate
+	}
+
+	now := s.now()
+	tmpl := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   cn,
+			Organization: csr.Subject.Organization,
+		},
+		DNSNames:              csr.DNSNames,
+		IPAddresses:           csr.IPAddresses,
+		SerialNumber:          serial,
+		NotBefore:             now,
+		NotAfter:              now.Add(profile.MaxValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           profile.Usages,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, csr.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.SerialStore != nil {
+		if err := s.SerialStore.Record(ctx, profileName, serial, now.Add(profile.MaxValidity)); err != nil {
+			return nil, fmt.Errorf("unable to persist issued serial: %v", err)
+		}
+	}
+
+	return certsutil.EncodeCertPEM(cert), nil
+}
+
+func (s *Signer) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func parseCSR(csrPEM []byte) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != csrBlockType {
+		return nil, fmt.Errorf("no CSR PEM block found")
+	}
+	return x509.ParseCertificateRequest(block.Bytes)
+}
+
+// SerialStore persists issued certificate serials to a ConfigMap, keyed by
+// serial number, so a revocation list can later be produced by diffing
+// against what is actually still in use.
+type SerialStore struct {
+	Client    client.Client
+	Namespace string
+	Name      string
+}
+
+// Record adds serial to the store's ConfigMap, creating it on first use.
+func (s *SerialStore) Record(ctx context.Context, profileName string, serial *big.Int, expiresAt time.Time) error {
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: s.Namespace, Name: s.Name}
+	err := s.Client.Get(ctx, key, cm)
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: s.Namespace, Name: s.Name},
+			Data:       map[string]string{serial.String(): fmt.Sprintf("%s,%s", profileName, expiresAt.UTC().Format(time.RFC3339))},
+		}
+		return s.Client.Create(ctx, cm)
+	}
+	if err != nil {
+		return err
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[serial.String()] = fmt.Sprintf("%s,%s", profileName, expiresAt.UTC().Format(time.RFC3339))
+	return s.Client.Update(ctx, cm)
+}
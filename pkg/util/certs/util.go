@@ -224,21 +224,152 @@ func NewCertConfig(cn string, org []string, altNames certutil.AltNames, notAfter
 	}
 }
 
+// CAProvider supplies the CA certificate and signer for a named CA (e.g.
+// "karmada", "etcd-ca", "front-proxy-ca"). Implementations decide whether
+// the CA is generated on the fly, supplied by the operator user, or backed
+// by an external KMS/HSM.
+type CAProvider interface {
+	// CertAndKey returns the CA certificate and a crypto.Signer able to sign
+	// with its private key for the CA named cn.
+	CertAndKey(cn string) (*x509.Certificate, crypto.Signer, error)
+}
+
+// SelfSignedCAProvider generates a new self-signed CA for every name it is
+// asked for. This is GenCerts' historical behavior.
+type SelfSignedCAProvider struct{}
+
+// CertAndKey implements CAProvider.
+func (SelfSignedCAProvider) CertAndKey(cn string) (*x509.Certificate, crypto.Signer, error) {
+	return NewCACertAndKey(cn)
+}
+
+// CAKeyPair is a PEM-encoded CA certificate and private key pair.
+type CAKeyPair struct {
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// SecretCAProvider loads CA certificate/key pairs supplied by the operator
+// user, keyed by CA name, e.g. from a mounted Kubernetes Secret.
+type SecretCAProvider struct {
+	CAs map[string]CAKeyPair
+}
+
+// CertAndKey implements CAProvider.
+func (p SecretCAProvider) CertAndKey(cn string) (*x509.Certificate, crypto.Signer, error) {
+	pair, ok := p.CAs[cn]
+	if !ok {
+		return nil, nil, fmt.Errorf("no supplied CA found for %q", cn)
+	}
+
+	block, _ := pem.Decode(pair.CertPEM)
+	if block == nil {
+		return nil, nil, fmt.Errorf("no PEM data found in supplied CA certificate for %q", cn)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse supplied CA certificate for %q: %v", cn, err)
+	}
+	if err := ValidateCACertificate(cert); err != nil {
+		return nil, nil, fmt.Errorf("supplied CA certificate for %q is invalid: %v", cn, err)
+	}
+
+	rawKey, err := keyutil.ParsePrivateKeyPEM(pair.KeyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse supplied CA key for %q: %v", cn, err)
+	}
+	signer, ok := rawKey.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("supplied CA key for %q does not implement crypto.Signer", cn)
+	}
+
+	return cert, signer, nil
+}
+
+// DelegatingCAProvider holds only the CA certificate in memory; signing is
+// delegated to a crypto.Signer backed by an external KMS/HSM, so the CA
+// private key material never enters process memory.
+type DelegatingCAProvider struct {
+	Certs   map[string]*x509.Certificate
+	Signers map[string]crypto.Signer
+}
+
+// CertAndKey implements CAProvider.
+func (p DelegatingCAProvider) CertAndKey(cn string) (*x509.Certificate, crypto.Signer, error) {
+	cert, ok := p.Certs[cn]
+	if !ok {
+		return nil, nil, fmt.Errorf("no CA certificate registered for %q", cn)
+	}
+	signer, ok := p.Signers[cn]
+	if !ok {
+		return nil, nil, fmt.Errorf("no delegated signer registered for %q", cn)
+	}
+	return cert, signer, nil
+}
+
+// ValidateCACertificate checks that cert is usable as a CA: the basic
+// constraints CA bit must be set and it must carry KeyUsageCertSign. Shared
+// by SecretCAProvider and the operator's validating admission webhook so a
+// user-supplied CA is rejected at apply time rather than at reconcile time.
+func ValidateCACertificate(cert *x509.Certificate) error {
+	if !cert.IsCA {
+		return errors.New("certificate does not have the CA basic constraint set")
+	}
+	if cert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return errors.New("certificate does not have the KeyUsageCertSign key usage")
+	}
+	return nil
+}
+
+// ValidateCACertPEM parses certPEM and runs it through ValidateCACertificate,
+// so callers working directly off a Secret's tls.crt bytes (the validating
+// admission webhook) don't each reimplement the decode/parse step.
+func ValidateCACertPEM(certPEM []byte) error {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return errors.New("no PEM data found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("unable to parse certificate: %v", err)
+	}
+	return ValidateCACertificate(cert)
+}
+
+// encodeCAKeyIfExportable PEM-encodes a CA private key when it is a
+// concrete key type we can marshal. CAs backed by a DelegatingCAProvider
+// hold no local key material, so callers must not assume a CA key entry is
+// always produced.
+func encodeCAKeyIfExportable(key crypto.Signer) ([]byte, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		return keyutil.MarshalPrivateKeyToPEM(key)
+	default:
+		return nil, nil
+	}
+}
+
 // GenCerts Create CA certificate and sign etcd karmada certificate.
-func GenCerts(etcdServerCertCfg, etcdClientCertCfg, karmadaCertCfg, apiserverCertCfg, frontProxyClientCertCfg *CertsConfig) (map[string][]byte, error) {
-	caCert, caKey, err := NewCACertAndKey("karmada")
+func GenCerts(caProvider CAProvider, etcdServerCertCfg, etcdClientCertCfg, karmadaCertCfg, apiserverCertCfg, frontProxyClientCertCfg *CertsConfig) (map[string][]byte, error) {
+	if caProvider == nil {
+		caProvider = SelfSignedCAProvider{}
+	}
+
+	caCert, caKey, err := caProvider.CertAndKey("karmada")
 	if err != nil {
 		return nil, err
 	}
 
 	data := make(map[string][]byte)
 
-	encodedCAKey, err := keyutil.MarshalPrivateKeyToPEM(caKey)
+	encodedCAKey, err := encodeCAKeyIfExportable(caKey)
 	if err != nil {
 		return nil, err
 	}
 	encodedCACert := EncodeCertPEM(caCert)
-	data["ca.key"] = encodedCAKey
+	if encodedCAKey != nil {
+		data["ca.key"] = encodedCAKey
+	}
 	data["ca.crt"] = encodedCACert
 
 	karmadaCert, karmadaKey, err := NewCertAndKey(caCert, caKey, karmadaCertCfg)
@@ -265,16 +396,18 @@ func GenCerts(etcdServerCertCfg, etcdClientCertCfg, karmadaCertCfg, apiserverCer
 	data["apiserver.key"] = encodedApiserverKey
 	data["apiserver.crt"] = encodedApiserverCert
 
-	frontProxyCaCert, frontProxyCaKey, err := NewCACertAndKey("front-proxy-ca")
+	frontProxyCaCert, frontProxyCaKey, err := caProvider.CertAndKey("front-proxy-ca")
 	if err != nil {
 		return nil, err
 	}
-	encodedFrontProxyCaKey, err := keyutil.MarshalPrivateKeyToPEM(frontProxyCaKey)
+	encodedFrontProxyCaKey, err := encodeCAKeyIfExportable(frontProxyCaKey)
 	if err != nil {
 		return nil, err
 	}
 	encodedFrontProxyCaCert := EncodeCertPEM(frontProxyCaCert)
-	data["front-proxy-ca.key"] = encodedFrontProxyCaKey
+	if encodedFrontProxyCaKey != nil {
+		data["front-proxy-ca.key"] = encodedFrontProxyCaKey
+	}
 	data["front-proxy-ca.crt"] = encodedFrontProxyCaCert
 
 	frontProxyClientCert, frontProxyClientKey, err := NewCertAndKey(frontProxyCaCert, frontProxyCaKey, frontProxyClientCertCfg)
@@ -289,16 +422,18 @@ func GenCerts(etcdServerCertCfg, etcdClientCertCfg, karmadaCertCfg, apiserverCer
 	data["front-proxy-client.key"] = encodedFrontProxyClientKey
 	data["front-proxy-client.crt"] = encodedFrontProxyClientCert
 
-	etcdCaCert, etcdCaKey, err := NewCACertAndKey("etcd-ca")
+	etcdCaCert, etcdCaKey, err := caProvider.CertAndKey("etcd-ca")
 	if err != nil {
 		return nil, err
 	}
-	encodedEtcdCaKey, err := keyutil.MarshalPrivateKeyToPEM(etcdCaKey)
+	encodedEtcdCaKey, err := encodeCAKeyIfExportable(etcdCaKey)
 	if err != nil {
 		return nil, err
 	}
 	encodedEtcdCaCert := EncodeCertPEM(etcdCaCert)
-	data["etcd-ca.key"] = encodedEtcdCaKey
+	if encodedEtcdCaKey != nil {
+		data["etcd-ca.key"] = encodedEtcdCaKey
+	}
 	data["etcd-ca.crt"] = encodedEtcdCaCert
 
 	etcdServerCert, etcdServerKey, err := NewCertAndKey(etcdCaCert, etcdCaKey, etcdServerCertCfg)
@@ -0,0 +1,326 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorapi "github.com/firefly-io/karmada-operator/pkg/apis/operator/v1alpha1"
+	certsutil "github.com/firefly-io/karmada-operator/pkg/util/certs"
+)
+
+// nodePortRange mirrors the kube-apiserver default
+// --service-node-port-range of 30000-32767.
+var nodePortRange = struct{ min, max int32 }{min: 30000, max: 32767}
+
+// ValidatingAdmission rejects a Karmada spec that would only fail later at
+// reconcile time, so `kubectl apply` fails fast with an actionable reason.
+type ValidatingAdmission struct {
+	Client  client.Client
+	decoder *admission.Decoder
+}
+
+// Check if our ValidatingAdmission implements necessary interfaces.
+var _ admission.Handler = &ValidatingAdmission{}
+var _ admission.DecoderInjector = &ValidatingAdmission{}
+var _ inject.Client = &ValidatingAdmission{}
+
+// NewValidatingHandler builds a new admission.Handler.
+func NewValidatingHandler() admission.Handler {
+	return &ValidatingAdmission{}
+}
+
+// Handle yields a response to an AdmissionRequest.
+func (v *ValidatingAdmission) Handle(ctx context.Context, req admission.Request) admission.Response {
+	karmada := &operatorapi.Karmada{}
+	if err := v.decoder.Decode(req, karmada); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	klog.InfoS("Validating Karmada", "karmada", klog.KObj(karmada))
+
+	if errs := v.validate(ctx, karmada); len(errs) > 0 {
+		return admission.Denied(strings.Join(errs, "; "))
+	}
+
+	return admission.Allowed("")
+}
+
+// validate runs every check and collects their messages instead of failing
+// fast, so a single `kubectl apply` surfaces every problem at once.
+func (v *ValidatingAdmission) validate(ctx context.Context, karmada *operatorapi.Karmada) []string {
+	var errs []string
+
+	errs = append(errs, validateImages(karmada)...)
+	errs = append(errs, validateServiceType(karmada)...)
+	errs = append(errs, validateAltNames(karmada)...)
+	errs = append(errs, validateCertNotAfter(karmada)...)
+	errs = append(errs, v.validateSecretRefs(ctx, karmada)...)
+
+	return errs
+}
+
+// componentImage names a single component's image reference, as carried by
+// Spec.Components.<Component>.ImageRepository/ImageTag.
+type componentImage struct {
+	field      string
+	repository string
+	tag        string
+}
+
+// componentImages collects every component's image reference in one place
+// so validateImages doesn't have to know each component's concrete field
+// layout.
+func componentImages(karmada *operatorapi.Karmada) []componentImage {
+	c := karmada.Spec.Components
+	var images []componentImage
+	add := func(field string, component *operatorapi.ComponentSpec) {
+		if component == nil {
+			return
+		}
+		images = append(images, componentImage{field: field, repository: component.ImageRepository, tag: component.ImageTag})
+	}
+
+	add("karmadaAPIServer", c.KarmadaAPIServer)
+	add("karmadaControllerManager", c.KarmadaControllerManager)
+	add("karmadaScheduler", c.KarmadaScheduler)
+	add("karmadaWebhook", c.KarmadaWebhook)
+	add("karmadaAggregatedAPIServer", c.KarmadaAggregatedAPIServer)
+	add("etcd", c.Etcd)
+
+	return images
+}
+
+// validateImages checks every component's ImageRepository/ImageTag look
+// like a well-formed image reference.
+func validateImages(karmada *operatorapi.Karmada) []string {
+	var errs []string
+	for _, img := range componentImages(karmada) {
+		if img.repository != "" && !validImageRepository(img.repository) {
+			errs = append(errs, fmt.Sprintf("spec.components.%s.imageRepository %q is not a valid image repository", img.field, img.repository))
+		}
+		if img.tag != "" && !validImageTag(img.tag) {
+			errs = append(errs, fmt.Sprintf("spec.components.%s.imageTag %q is not a valid image tag", img.field, img.tag))
+		}
+	}
+	return errs
+}
+
+// imageRepositoryRE is docker/distribution's repository name grammar: an
+// optional domain ("localhost", a dotted host, or any host with a port)
+// followed by lowercase path components.
+var imageRepositoryRE = func() *regexp.Regexp {
+	hostComponent := `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])`
+	domain := `(?:localhost(?::[0-9]+)?|` + hostComponent + `(?:\.` + hostComponent + `)+(?::[0-9]+)?|` + hostComponent + `:[0-9]+)`
+	pathComponent := `[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*`
+	return regexp.MustCompile(`^(?:` + domain + `/)?` + pathComponent + `(?:/` + pathComponent + `)*$`)
+}()
+
+// validImageRepository accepts "[host[:port]/]path(/path)*", the same
+// shape docker/distribution's reference package parses.
+func validImageRepository(repo string) bool {
+	return imageRepositoryRE.MatchString(repo)
+}
+
+// validImageTag applies the same character set docker/distribution enforces
+// for tags: [A-Za-z0-9_][A-Za-z0-9._-]{0,127}.
+func validImageTag(tag string) bool {
+	if len(tag) == 0 || len(tag) > 128 {
+		return false
+	}
+	for i, r := range tag {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '_' && i == 0:
+		case (r == '.' || r == '-' || r == '_') && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validateServiceType rejects a NodePort outside nodePortRange when
+// Spec.APIServer.ServiceType is NodePort. The range is the kube-apiserver
+// default; a cluster's own --service-node-port-range setting is not read.
+func validateServiceType(karmada *operatorapi.Karmada) []string {
+	apiServer := karmada.Spec.APIServer
+	if apiServer == nil || apiServer.ServiceType != corev1.ServiceTypeNodePort || apiServer.NodePort == 0 {
+		return nil
+	}
+	if apiServer.NodePort < nodePortRange.min || apiServer.NodePort > nodePortRange.max {
+		return []string{fmt.Sprintf("spec.apiServer.nodePort %d is outside the default node port range [%d-%d]",
+			apiServer.NodePort, nodePortRange.min, nodePortRange.max)}
+	}
+	return nil
+}
+
+// ipShapedRE matches an IPv4 dotted-quad shape, e.g. "10.0.0.300". Digit-only
+// labels are valid DNS1123 subdomains, so a malformed address like that would
+// otherwise fall through to the DNS branch below and be accepted.
+var ipShapedRE = regexp.MustCompile(`^[0-9]{1,3}(\.[0-9]{1,3}){3}$`)
+
+// validateAltNames rejects any CertSANs entry that is neither a valid IP
+// address nor a valid DNS name.
+func validateAltNames(karmada *operatorapi.Karmada) []string {
+	var errs []string
+	if karmada.Spec.APIServer == nil {
+		return errs
+	}
+	for _, san := range karmada.Spec.APIServer.CertSANs {
+		if net.ParseIP(san) != nil {
+			continue
+		}
+		if ipShapedRE.MatchString(san) || len(validation.IsDNS1123Subdomain(san)) > 0 {
+			errs = append(errs, fmt.Sprintf("spec.apiServer.certSANs contains %q, which is neither a valid IP address nor a valid DNS name", san))
+		}
+	}
+	return errs
+}
+
+// caStanza names a single entry of Spec.CertificateAuthority: one stanza
+// per CA the operator manages.
+type caStanza struct {
+	field     string
+	secretRef *corev1.LocalObjectReference
+	notAfter  *time.Time
+}
+
+func caStanzas(karmada *operatorapi.Karmada) []caStanza {
+	ca := karmada.Spec.CertificateAuthority
+	if ca == nil {
+		return nil
+	}
+	var stanzas []caStanza
+	add := func(field string, cfg *operatorapi.CAConfig) {
+		if cfg == nil {
+			return
+		}
+		stanzas = append(stanzas, caStanza{field: field, secretRef: cfg.SecretRef, notAfter: cfg.NotAfter})
+	}
+	add("root", ca.Root)
+	add("etcd", ca.Etcd)
+	add("frontProxy", ca.FrontProxy)
+	return stanzas
+}
+
+// validateCertNotAfter rejects a user-supplied NotAfter that has already
+// passed.
+func validateCertNotAfter(karmada *operatorapi.Karmada) []string {
+	var errs []string
+	now := time.Now()
+	for _, stanza := range caStanzas(karmada) {
+		if stanza.notAfter != nil && stanza.notAfter.Before(now) {
+			errs = append(errs, fmt.Sprintf("spec.certificateAuthority.%s.notAfter %s is in the past",
+				stanza.field, stanza.notAfter.Format(time.RFC3339)))
+		}
+	}
+	return errs
+}
+
+// validateSecretRefs checks that every referenced Secret exists and carries
+// the keys its consumer expects: a user-supplied CA and etcd's external
+// credentials.
+func (v *ValidatingAdmission) validateSecretRefs(ctx context.Context, karmada *operatorapi.Karmada) []string {
+	var errs []string
+
+	for _, stanza := range caStanzas(karmada) {
+		if stanza.secretRef == nil {
+			continue
+		}
+		field := fmt.Sprintf("spec.certificateAuthority.%s.secretRef", stanza.field)
+		secret, keyErrs := v.checkSecretKeys(ctx, karmada.Namespace, *stanza.secretRef, field, "tls.crt", "tls.key")
+		if len(keyErrs) > 0 {
+			errs = append(errs, keyErrs...)
+			continue
+		}
+		if secret == nil {
+			continue
+		}
+		if err := certsutil.ValidateCACertPEM(secret.Data["tls.crt"]); err != nil {
+			errs = append(errs, fmt.Sprintf("%s Secret %q's \"tls.crt\" is not usable as a CA: %v", field, stanza.secretRef.Name, err))
+		}
+	}
+
+	if etcd := karmada.Spec.Components.Etcd; etcd != nil && etcd.External != nil && etcd.External.SecretRef != nil {
+		_, keyErrs := v.checkSecretKeys(ctx, karmada.Namespace, *etcd.External.SecretRef,
+			"spec.components.etcd.external.secretRef", "ca.crt", "client.crt", "client.key")
+		errs = append(errs, keyErrs...)
+	}
+
+	return errs
+}
+
+// checkSecretKeys fetches the Secret named by ref and confirms it carries
+// every key in wantKeys, returning a single actionable message per problem.
+// The fetched Secret is also returned so a caller needing to inspect its
+// contents further (e.g. parsing a CA certificate) doesn't have to fetch it
+// again.
+func (v *ValidatingAdmission) checkSecretKeys(ctx context.Context, namespace string, ref corev1.LocalObjectReference, field string, wantKeys ...string) (*corev1.Secret, []string) {
+	if ref.Name == "" {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := v.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil, []string{fmt.Sprintf("%s references Secret %q, which does not exist in namespace %q", field, ref.Name, namespace)}
+	}
+	if err != nil {
+		return nil, []string{fmt.Sprintf("%s: unable to fetch Secret %q: %v", field, ref.Name, err)}
+	}
+
+	var missing []string
+	for _, key := range wantKeys {
+		if _, ok := secret.Data[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return secret, []string{fmt.Sprintf("%s Secret %q is missing required key(s): %s", field, ref.Name, strings.Join(missing, ", "))}
+	}
+	return secret, nil
+}
+
+// InjectDecoder implements admission.DecoderInjector interface.
+// A decoder will be automatically injected.
+func (v *ValidatingAdmission) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// InjectClient implements inject.Client interface.
+// A client will be automatically injected.
+func (v *ValidatingAdmission) InjectClient(c client.Client) error {
+	v.Client = c
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"testing"
+
+	operatorapi "github.com/firefly-io/karmada-operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestValidateAltNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		sans    []string
+		wantErr bool
+	}{
+		{name: "valid IPv4", sans: []string{"10.0.0.1"}},
+		{name: "valid DNS name", sans: []string{"karmada-apiserver.karmada-system.svc"}},
+		{name: "malformed dotted-quad rejected", sans: []string{"10.0.0.300"}, wantErr: true},
+		{name: "digit-only DNS label still accepted", sans: []string{"10"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			karmada := &operatorapi.Karmada{
+				Spec: operatorapi.KarmadaSpec{
+					APIServer: &operatorapi.APIServerSpec{CertSANs: tt.sans},
+				},
+			}
+			errs := validateAltNames(karmada)
+			if got := len(errs) > 0; got != tt.wantErr {
+				t.Errorf("validateAltNames(%v) errs = %v, want error = %v", tt.sans, errs, tt.wantErr)
+			}
+		})
+	}
+}